@@ -0,0 +1,101 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"math"
+	"sync"
+)
+
+// AnswerHandler is called with the messages of the user in the channel it was registered for,
+// returns true if it consumed the message so the next monitors (including the command handler) don't see it.
+// It runs in the monitors' goroutine so it should not block.
+type AnswerHandler func(m *discordgo.Message) bool
+
+type answerKey struct {
+	channelID int64
+	userID    int64
+}
+
+// AnswerRouter routes messages to the handlers waiting for an answer from their author in their channel,
+// e.g a paginator asking for the page to jump to. The bot's router is fed by the "answers" monitor which runs first.
+type AnswerRouter struct {
+	handlers map[answerKey]map[uint64]AnswerHandler // (channel ID, user ID) -> handler ID -> handler
+	nextID   uint64
+	lock     sync.RWMutex
+}
+
+func NewAnswerRouter() *AnswerRouter {
+	return &AnswerRouter{handlers: make(map[answerKey]map[uint64]AnswerHandler)}
+}
+
+// Register adds a handler for the messages of the user in the channel.
+// Returns a function that removes the handler, it is safe to call more than once.
+func (router *AnswerRouter) Register(channelID, userID int64, handler AnswerHandler) func() {
+	key := answerKey{channelID, userID}
+	router.lock.Lock()
+	router.nextID++
+	id := router.nextID
+	if router.handlers[key] == nil {
+		router.handlers[key] = make(map[uint64]AnswerHandler)
+	}
+	router.handlers[key][id] = handler
+	router.lock.Unlock()
+
+	return func() {
+		router.lock.Lock()
+		defer router.lock.Unlock()
+		handlers := router.handlers[key]
+		delete(handlers, id)
+		if len(handlers) == 0 {
+			delete(router.handlers, key)
+		}
+	}
+}
+
+// Len returns the number of channel and user pairs with handlers.
+func (router *AnswerRouter) Len() int {
+	router.lock.RLock()
+	defer router.lock.RUnlock()
+	return len(router.handlers)
+}
+
+// Dispatch calls the handlers waiting for the message, returns true if one of them consumed it.
+func (router *AnswerRouter) Dispatch(m *discordgo.Message) bool {
+	if m.Author == nil {
+		return false
+	}
+	router.lock.RLock()
+	registered := router.handlers[answerKey{m.ChannelID, m.Author.ID}]
+	handlers := make([]AnswerHandler, 0, len(registered))
+	for _, handler := range registered {
+		handlers = append(handlers, handler)
+	}
+	router.lock.RUnlock()
+
+	for _, handler := range handlers {
+		if handler(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddHandlers adds a session handler that dispatches created messages to the router, for routers not attached to a bot.
+// Returns a function that removes it.
+func (router *AnswerRouter) AddHandlers(s *discordgo.Session) func() {
+	return s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageCreate) {
+		router.Dispatch(m.Message)
+	})
+}
+
+// AnswersMonitor dispatches messages to bot.Answers before the other monitors and stops them from running on consumed ones.
+func AnswersMonitor(bot *Bot, ctx *MonitorContext) {
+	if bot.Answers.Dispatch(ctx.Message) {
+		ctx.StopPropagation()
+	}
+}
+
+// newAnswersMonitor creates the monitor running AnswersMonitor, synchronous and first so nothing else sees the answers.
+func newAnswersMonitor() *Monitor {
+	return NewMonitor("answers", AnswersMonitor).SetPriority(math.MaxInt32).SetAsync(false)
+}
@@ -0,0 +1,37 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"testing"
+)
+
+func TestAnswerRouter(t *testing.T) {
+	router := NewAnswerRouter()
+	var got []string
+	unregister := router.Register(1, 2, func(m *discordgo.Message) bool {
+		got = append(got, m.Content)
+		return m.Content == "3"
+	})
+
+	message := func(channelID, userID int64, content string) *discordgo.Message {
+		return &discordgo.Message{ChannelID: channelID, Author: &discordgo.User{ID: userID}, Content: content}
+	}
+	if router.Dispatch(message(1, 3, "other user")) || router.Dispatch(message(4, 2, "other channel")) {
+		t.Error("Expected messages of other users and channels to not be consumed")
+	}
+	if router.Dispatch(message(1, 2, "nope")) {
+		t.Error("Expected the handler to not consume an invalid answer")
+	}
+	if !router.Dispatch(message(1, 2, "3")) {
+		t.Error("Expected the handler to consume the answer")
+	}
+	if len(got) != 2 {
+		t.Errorf("Expected the handler to only see the user's messages in the channel, got %q", got)
+	}
+
+	unregister()
+	unregister()
+	if router.Dispatch(message(1, 2, "3")) || router.Len() != 0 {
+		t.Error("Expected the handler to be unregistered")
+	}
+}
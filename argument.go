@@ -1,7 +1,7 @@
 package gocto
 
 import (
-	"fmt"
+	"errors"
 	"github.com/jonas747/discordgo"
 	"regexp"
 	"strconv"
@@ -83,6 +83,11 @@ var MentionRegex = regexp.MustCompile("^(?:<@!?)?(\\d{17,19})>?$")
 // The Regexp used for matching channel mentions.
 var ChannelMentionRegex = regexp.MustCompile("^(?:<#)?(\\d{17,19})>?$")
 
+// argumentError returns an error with the localized key, the tag's name and type are available as {name} and {type}.
+func argumentError(ctx *CommandContext, key string, tag *UsageTag) error {
+	return errors.New(ctx.Localize(key, LocaleArgs{"name": tag.Name, "type": tag.Type}))
+}
+
 // Parses the raw argument as specified in tag in context of ctx
 func ParseArgument(ctx *CommandContext, tag *UsageTag, raw string) (*Argument, error) {
 	if raw == "" {
@@ -99,7 +104,10 @@ func ParseArgument(ctx *CommandContext, tag *UsageTag, raw string) (*Argument, e
 		fallthrough
 	case "int":
 		val, err := strconv.ParseInt(raw, 10, 64)
-		return arg(val), err
+		if err != nil {
+			return nil, argumentError(ctx, "ARGUMENT_INVALID_NUMBER", tag)
+		}
+		return arg(val), nil
 	case "member":
 		match := MentionRegex.FindStringSubmatch(raw)
 
@@ -109,12 +117,12 @@ func ParseArgument(ctx *CommandContext, tag *UsageTag, raw string) (*Argument, e
 		}
 
 		if len(match) < 2 {
-			return nil, fmt.Errorf("**%s** must be a valid member mention or ID.", tag.Name)
+			return nil, argumentError(ctx, "ARGUMENT_INVALID_MEMBER", tag)
 		}
 		i, _ := strconv.ParseInt(match[1], 10, 64)
 		member := ctx.Member(i)
 		if member == nil {
-			return nil, fmt.Errorf("That member cannot be found in this server.")
+			return nil, argumentError(ctx, "ARGUMENT_MEMBER_NOT_FOUND", tag)
 		}
 		return arg(member), nil
 	case "user":
@@ -127,13 +135,13 @@ func ParseArgument(ctx *CommandContext, tag *UsageTag, raw string) (*Argument, e
 		}
 
 		if len(match) < 2 {
-			return nil, fmt.Errorf("**%s** must be a valid user mention or ID.", tag.Name)
+			return nil, argumentError(ctx, "ARGUMENT_INVALID_USER", tag)
 		}
 		i, _ := strconv.ParseInt(match[1], 10, 64)
 		user, _ := ctx.FetchUser(i)
 
 		if user == nil {
-			return nil, fmt.Errorf("That user cannot be found.")
+			return nil, argumentError(ctx, "ARGUMENT_USER_NOT_FOUND", tag)
 		}
 
 		return arg(user), nil
@@ -143,22 +151,22 @@ func ParseArgument(ctx *CommandContext, tag *UsageTag, raw string) (*Argument, e
 		match := ChannelMentionRegex.FindStringSubmatch(raw)
 
 		if len(match) < 2 {
-			return nil, fmt.Errorf("**%s** must be a valid channel mention or ID.", tag.Name)
+			return nil, argumentError(ctx, "ARGUMENT_INVALID_CHANNEL", tag)
 		}
 		i, _ := strconv.ParseInt(match[1], 10, 64)
 		channel, _ := ctx.Session.State.Channel(i)
 
 		if channel == nil {
-			return nil, fmt.Errorf("That channel cannot be found.")
+			return nil, argumentError(ctx, "ARGUMENT_CHANNEL_NOT_FOUND", tag)
 		}
 
 		return arg(channel), nil
 	case "literal":
 		if raw != tag.Name {
-			return nil, fmt.Errorf("Literal argument must be **%s**", tag.Name)
+			return nil, argumentError(ctx, "ARGUMENT_INVALID_LITERAL", tag)
 		}
 		return arg(raw), nil
 	default:
-		return nil, fmt.Errorf("The argument type '%s' is invalid.", tag.Type)
+		return nil, argumentError(ctx, "ARGUMENT_INVALID_TYPE", tag)
 	}
 }
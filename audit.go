@@ -0,0 +1,170 @@
+package gocto
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// LanguageAudit reports the differences of a language from a reference language.
+type LanguageAudit struct {
+	Language   string   // The audited language's name.
+	Reference  string   // The reference language's name, usually the default locale.
+	Missing    []string // Keys in the reference that the language lacks.
+	Extra      []string // Keys in the language that the reference doesn't have.
+	Mismatched []string // Keys whose placeholders or format verbs differ from the reference.
+}
+
+// OK returns true if nothing is missing, extra or mismatched.
+func (a *LanguageAudit) OK() bool {
+	return len(a.Missing) == 0 && len(a.Extra) == 0 && len(a.Mismatched) == 0
+}
+
+var printfVerbRegex = regexp.MustCompile("%[-+# 0]*[0-9*]*(?:\\.[0-9*]+)?[a-zA-Z%]")
+
+// messagePlaceholders returns the sorted printf verbs and top level placeholder names of a message.
+func messagePlaceholders(message string) []string {
+	res := make([]string, 0)
+	for _, verb := range printfVerbRegex.FindAllString(message, -1) {
+		if verb != "%%" {
+			res = append(res, verb)
+		}
+	}
+	for i := 0; i < len(message); i++ {
+		switch message[i] {
+		case '\'':
+			// Skip quoted text, see FormatMessage.
+			if i+1 < len(message) && message[i+1] == '\'' {
+				i++
+			} else if i+1 < len(message) && strings.IndexByte("{}#", message[i+1]) != -1 {
+				end := strings.IndexByte(message[i+1:], '\'')
+				if end == -1 {
+					i = len(message)
+				} else {
+					i += end + 1
+				}
+			}
+		case '{':
+			end := matchingBrace(message, i)
+			if end == -1 {
+				return res
+			}
+			name := strings.TrimSpace(strings.SplitN(message[i+1:end], ",", 2)[0])
+			res = append(res, "{"+name+"}")
+			i = end
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// AuditLanguage compares lang against reference.
+func AuditLanguage(lang, reference *Language) *LanguageAudit {
+	audit := &LanguageAudit{
+		Language:   lang.Name,
+		Reference:  reference.Name,
+		Missing:    make([]string, 0),
+		Extra:      make([]string, 0),
+		Mismatched: make([]string, 0),
+	}
+
+	for key, ref := range reference.Keys {
+		v, ok := lang.Keys[key]
+		if !ok {
+			audit.Missing = append(audit.Missing, key)
+			continue
+		}
+		if strings.Join(messagePlaceholders(v), " ") != strings.Join(messagePlaceholders(ref), " ") {
+			audit.Mismatched = append(audit.Mismatched, key)
+		}
+	}
+
+	for key := range lang.Keys {
+		if _, ok := reference.Keys[key]; !ok {
+			audit.Extra = append(audit.Extra, key)
+		}
+	}
+
+	sort.Strings(audit.Missing)
+	sort.Strings(audit.Extra)
+	sort.Strings(audit.Mismatched)
+	return audit
+}
+
+// AuditLanguages audits every language against the default locale, sorted by name.
+// Keys inherited from a language's parents (see LocaleChain) are not reported as missing.
+func (bot *Bot) AuditLanguages() []*LanguageAudit {
+	names := make([]string, 0, len(bot.Languages))
+	for name := range bot.Languages {
+		if name != bot.DefaultLocale.Name {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	audits := make([]*LanguageAudit, 0, len(names))
+	for _, name := range names {
+		lang := bot.Languages[name]
+		flat := NewLanguage(name)
+		chain := bot.LocaleChain(name)
+		for i := len(chain) - 1; i >= 0; i-- {
+			if chain[i] != bot.DefaultLocale {
+				flat.Merge(chain[i])
+			}
+		}
+
+		audit := AuditLanguage(flat, bot.DefaultLocale)
+		// Only report the keys this language added itself.
+		audit.Extra = AuditLanguage(lang, bot.DefaultLocale).Extra
+		audits = append(audits, audit)
+	}
+	return audits
+}
+
+// keyFields joins the keys into field values that fit in EmbedLimitFieldValue.
+func keyFields(keys []string) []string {
+	var values []string
+	current := ""
+	for _, key := range keys {
+		key = "`" + key + "`"
+		if current != "" && utf8.RuneCountInString(current)+len(", ")+utf8.RuneCountInString(key) > EmbedLimitFieldValue {
+			values = append(values, current)
+			current = ""
+		}
+		if current != "" {
+			current += ", "
+		}
+		current += key
+	}
+	if current != "" {
+		values = append(values, current)
+	}
+	return values
+}
+
+// TestingT is the subset of *testing.T used by CheckLanguages.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// CheckLanguages reports every audit problem of the bot's languages to t, for use in tests:
+//
+//	func TestLanguages(t *testing.T) {
+//		gocto.CheckLanguages(t, bot)
+//	}
+func CheckLanguages(t TestingT, bot *Bot) {
+	t.Helper()
+	for _, audit := range bot.AuditLanguages() {
+		for _, key := range audit.Missing {
+			t.Errorf("%s: missing key %s", audit.Language, key)
+		}
+		for _, key := range audit.Extra {
+			t.Errorf("%s: extra key %s not found in %s", audit.Language, key, audit.Reference)
+		}
+		for _, key := range audit.Mismatched {
+			t.Errorf("%s: key %s has different placeholders than %s", audit.Language, key, audit.Reference)
+		}
+	}
+}
@@ -1,33 +1,44 @@
 package gocto
 
 import (
+	"errors"
 	"fmt"
+	"github.com/Noctember/gocto/helpers"
 	"github.com/jonas747/discordgo"
 	"io"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 type CommandHandler func(ctx *CommandContext)
 
 type Command struct {
-	Name                string         // The command's name. (default: required)
-	Aliases             []string       // Aliases that point to this command. (default: [])
-	Run                 CommandHandler // The handler that actually runs the command. (default: required)
-	Enabled             bool           // Wether this command is enabled. (default: true)
-	Description         string         // The command's brief description. (default: "No Description Provided.")
-	Category            string         // The category this command belongs to. (default: required)
-	OwnerOnly           bool           // Wether this command can only be used by the owner. (default: false)
-	GuildOnly           bool           // Wether this command can only be ran on a guild. (default: false)
-	UsageString         string         // Usage string for this command. (default: "")
-	Usage               []*UsageTag    // Parsed usage tags for this command.
-	Cooldown            int            // Command cooldown in seconds. (default: 0)
-	Editable            bool           // Wether this command's response will be editable. (default: true)
-	RequiredPermissions int            // Permissions the user needs to run this command. (default: 0)
-	DeleteAfter         bool           // Deletes command when ran (default: false)
-	BotPermissions      int            // Permissions the bot needs to perform this command. (default: 0)
-	Override            bool           // Override message editting (default: true)
-	AvailableTags       string         // Shows available tags in help command (default: none)
+	Name                string              // The command's name. (default: required)
+	Aliases             []string            // Aliases that point to this command. (default: [])
+	Run                 CommandHandler      // The handler that actually runs the command. (default: required)
+	Enabled             bool                // Wether this command is enabled. (default: true)
+	Description         string              // The command's brief description. (default: "No Description Provided.")
+	Category            string              // The category this command belongs to. (default: required)
+	OwnerOnly           bool                // Wether this command can only be used by the owner. (default: false)
+	GuildOnly           bool                // Wether this command can only be ran on a guild. (default: false)
+	UsageString         string              // Usage string for this command. (default: "")
+	Usage               []*UsageTag         // Parsed usage tags for this command.
+	Cooldown            int                 // Command cooldown in seconds. (default: 0)
+	Editable            bool                // Wether this command's response will be editable. (default: true)
+	RequiredPermissions int                 // Permissions the user needs to run this command. (default: 0)
+	DeleteAfter         bool                // Deletes command when ran (default: false)
+	BotPermissions      int                 // Permissions the bot needs to perform this command. (default: 0)
+	Override            bool                // Override message editting (default: true)
+	AvailableTags       string              // Shows available tags in help command (default: none)
+	DescriptionKey      string              // Locale key of the description, Description is used if it isn't localized. (default: CMD_<NAME>_DESCRIPTION)
+	UsageKey            string              // Locale key of the usage shown in help, the humanized UsageString is used if it isn't localized. (default: CMD_<NAME>_USAGE)
+	LocaleAliases       map[string][]string // Aliases that point to this command only for a language and its children e.g "fr": ["aide"]. (default: {})
+	Sanitizer           *helpers.Sanitizer  // Sanitizes the args formatted into replies, nil to send them as is. (default: nil)
+	ResponseTTL         time.Duration       // How long until the responses are deleted, 0 to keep them. (default: 0)
+	ExpireTrigger       bool                // Wether the command's message is deleted too when ResponseTTL passes. (default: false)
 }
 
 func NewCommand(name string, category string, run CommandHandler) *Command {
@@ -46,12 +57,28 @@ func NewCommand(name string, category string, run CommandHandler) *Command {
 		RequiredPermissions: 0,
 		BotPermissions:      0,
 		DeleteAfter:         false,
+		ResponseTTL:         0,
+		ExpireTrigger:       false,
 		Usage:               make([]*UsageTag, 0),
 		Override:            true,
 		AvailableTags:       "",
+		DescriptionKey:      localeKey("CMD", name, "DESCRIPTION"),
+		UsageKey:            localeKey("CMD", name, "USAGE"),
+		LocaleAliases:       make(map[string][]string),
+		Sanitizer:           nil,
 	}
 }
 
+// localeKey joins the parts into an uppercase locale key e.g ("COMMAND", "ban-user") -> COMMAND_BAN_USER
+func localeKey(parts ...string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(strings.Join(parts, "_")))
+}
+
 func (c *Command) AddAliases(aliases ...string) *Command {
 	c.Aliases = append(c.Aliases, aliases...)
 	return c
@@ -62,11 +89,75 @@ func (c *Command) SetDescription(description string) *Command {
 	return c
 }
 
+// SetDescriptionKey sets the locale key of the description.
+func (c *Command) SetDescriptionKey(key string) *Command {
+	c.DescriptionKey = key
+	return c
+}
+
+// SetUsageKey sets the locale key of the usage shown in help.
+func (c *Command) SetUsageKey(key string) *Command {
+	c.UsageKey = key
+	return c
+}
+
+// AddLocaleAliases adds aliases that only work when the command is ran in the locale or one of its children.
+func (c *Command) AddLocaleAliases(locale string, aliases ...string) *Command {
+	c.LocaleAliases[locale] = append(c.LocaleAliases[locale], aliases...)
+	return c
+}
+
+// LocalizedName returns the first locale alias for the context's locale or the command's name.
+func (c *Command) LocalizedName(ctx *CommandContext) string {
+	for _, lang := range ctx.localeChain() {
+		if aliases := c.LocaleAliases[lang.Name]; len(aliases) > 0 {
+			return aliases[0]
+		}
+	}
+	return c.Name
+}
+
+// LocalizedAliases returns the aliases and the locale aliases for the context's locale.
+func (c *Command) LocalizedAliases(ctx *CommandContext) []string {
+	aliases := append([]string{}, c.Aliases...)
+	for _, lang := range ctx.localeChain() {
+		aliases = append(aliases, c.LocaleAliases[lang.Name]...)
+	}
+	return aliases
+}
+
+// LocalizedDescription returns the description in the context's locale.
+func (c *Command) LocalizedDescription(ctx *CommandContext) string {
+	return ctx.LocalizeDefault(c.DescriptionKey, c.Description)
+}
+
+// LocalizedCategory returns the category in the context's locale, localized with the key CATEGORY_<CATEGORY>.
+func (c *Command) LocalizedCategory(ctx *CommandContext) string {
+	return ctx.LocalizeDefault(localeKey("CATEGORY", c.Category), c.Category)
+}
+
+// LocalizedUsage returns the humanized usage in the context's locale.
+func (c *Command) LocalizedUsage(ctx *CommandContext) string {
+	return ctx.LocalizeDefault(c.UsageKey, HumanizeUsage(c.UsageString))
+}
+
 func (c *Command) Delete() *Command {
 	c.DeleteAfter = true
 	return c
 }
 
+// SetResponseTTL deletes the command's responses after ttl, 0 keeps them.
+func (c *Command) SetResponseTTL(ttl time.Duration) *Command {
+	c.ResponseTTL = ttl
+	return c
+}
+
+// SetExpireTrigger sets wether the command's message is deleted with its responses after ResponseTTL.
+func (c *Command) SetExpireTrigger(toggle bool) *Command {
+	c.ExpireTrigger = toggle
+	return c
+}
+
 func (c *Command) SetUsage(usage string) *Command {
 	c.UsageString = usage
 	usg, err := ParseUsage(usage)
@@ -112,6 +203,19 @@ func (c *Command) SetCooldown(cooldown int) *Command {
 	return c
 }
 
+// SetAvailableTags sets the flags shown in the help command e.g "--all --user=<user>"
+func (c *Command) SetAvailableTags(tags string) *Command {
+	c.AvailableTags = tags
+	return c
+}
+
+// SetSanitizer sanitizes the args formatted into the replies of this command e.g with helpers.NewSanitizer()
+// The args of Reply, ReplyNoEdit, ReplyLong and ReplyLocale are sanitized, the text they are formatted into is not.
+func (c *Command) SetSanitizer(sanitizer *helpers.Sanitizer) *Command {
+	c.Sanitizer = sanitizer
+	return c
+}
+
 func (c *Command) SetPermission(permbit int) *Command {
 	c.RequiredPermissions = permbit
 	return c
@@ -129,8 +233,11 @@ type CommandContext struct {
 	Guild       *discordgo.Guild   // The guild this command was ran on.
 	Flags       map[string]string  // Map of flags passed to the command. e.g --flag=yo
 	Locale      *Language          // The current language.
+	Locales     []*Language        // The languages to lookup keys from, starting with Locale.
 	RawArgs     []string           // The raw args that may not match the usage string.
 	InvokedName string             // The name this command was invoked as, this includes the used alias.
+	responded   bool               // Wether a response was sent in this run.
+	sendLock    sync.Mutex
 }
 
 type CommandError struct {
@@ -144,77 +251,159 @@ func (err *CommandError) Error() string {
 	return fmt.Sprint(err.Err)
 }
 
+// MessageLimit is the maximum length of a message's content.
+const MessageLimit = 2000
+
+// LongReply is what Reply does with content over MessageLimit.
+type LongReply int
+
+const (
+	LongReplyAsIs  LongReply = iota // Sends it as is, which Discord rejects.
+	LongReplySplit                  // Splits it into several messages on line or word boundaries.
+	LongReplyFile                   // Attaches it as a .txt file.
+)
+
 // Reply replies with a string.
 // It will call Sprintf() on the content if atleast one vararg is passed.
+// Content over MessageLimit is handled as set by bot.LongReplies, see ReplyLong.
 func (ctx *CommandContext) Reply(content string, args ...interface{}) (*discordgo.Message, error) {
+	if len(args) > 0 {
+		content = fmt.Sprintf(content, ctx.sanitizeArgs(args)...)
+	}
 
-	if !ctx.Command.Editable {
-		return ctx.ReplyNoEdit(content)
+	if ctx.Bot.LongReplies != LongReplyAsIs && utf8.RuneCountInString(content) > MessageLimit {
+		return ctx.ReplyLong(ctx.Bot.LongReplies, content)
 	}
+	return ctx.reply(content)
+}
 
+// ReplyLong replies with content that may be over MessageLimit.
+// With LongReplySplit it is split into several messages on line or word boundaries, code blocks cut by a split are closed
+// and reopened in the next message, the first message is the one edited when the command is edited.
+// With LongReplyFile it is attached as response.txt.
+// When the command doesn't Override the limit applies to the content with the response it is appended to.
+// It will call Sprintf() on the content if atleast one vararg is passed.
+func (ctx *CommandContext) ReplyLong(mode LongReply, content string, args ...interface{}) (*discordgo.Message, error) {
 	if len(args) > 0 {
-		content = fmt.Sprintf(content, args...)
+		content = fmt.Sprintf(content, ctx.sanitizeArgs(args)...)
+	}
+
+	full, rerun, err := ctx.appendedContent(content)
+	if err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(full) <= MessageLimit {
+		return ctx.Send(MessageOptions{Content: full, Replace: true})
 	}
 
-	m, ok := ctx.Bot.CommandEdits[ctx.Message.ID]
-	if !ok {
-		msg, err := ctx.Session.ChannelMessageSend(ctx.Channel.ID, content)
+	switch mode {
+	case LongReplySplit:
+		chunks := helpers.SplitText(full, helpers.SplitOptions{MaxLength: MessageLimit})
+		first, err := ctx.Send(MessageOptions{Content: chunks[0], Replace: true})
 		if err != nil {
 			return nil, err
 		}
-		ctx.Bot.CommandEdits[ctx.Message.ID] = msg.ID
-		return msg, nil
-	}
-	if !ctx.Command.Override {
-		old, _ := ctx.Session.ChannelMessage(ctx.Channel.ID, m)
-		return ctx.Session.ChannelMessageEditComplex(discordgo.NewMessageEdit(ctx.Channel.ID, m).
-			SetContent(old.Content + "\n" + content))
+		for _, chunk := range chunks[1:] {
+			if _, err := ctx.ReplyNoEdit(chunk); err != nil {
+				return first, err
+			}
+		}
+		return first, nil
+	case LongReplyFile:
+		if !rerun {
+			// The response of this run is kept, the file only has the new content.
+			full = content
+		}
+		return ctx.SendFile("response.txt", strings.NewReader(full), ctx.Localize("REPLY_TOO_LONG"))
 	}
-	return ctx.Session.ChannelMessageEditComplex(discordgo.NewMessageEdit(ctx.Channel.ID, m).
-		SetContent(content))
+	return ctx.Send(MessageOptions{Content: full, Replace: true})
+}
+
+// reply sends or edits the response with content as is.
+func (ctx *CommandContext) reply(content string) (*discordgo.Message, error) {
+	return ctx.Send(MessageOptions{Content: content})
 }
 
 // ReplyNoEdit replies with content but does not consider editable option of the command.
 func (ctx *CommandContext) ReplyNoEdit(content string, args ...interface{}) (*discordgo.Message, error) {
 	// See the comments in Reply
 	if len(args) > 0 {
-		content = fmt.Sprintf(content, args...)
+		content = fmt.Sprintf(content, ctx.sanitizeArgs(args)...)
 	}
-	return ctx.Session.ChannelMessageSend(ctx.Channel.ID, content)
+	return ctx.Send(MessageOptions{Content: content, NoEdit: true})
 }
 
-// ReplyLocale sends a localized key for the current context's locale.
-func (ctx *CommandContext) ReplyLocale(key string, args ...interface{}) (*discordgo.Message, error) {
-	res := ctx.Locale.Get(key, args...)
+// Localize resolves key for the current context's locale, falling back through its parents and the default locale.
+// Formatting errors are reported to the ErrorHandler as a *LocaleError.
+func (ctx *CommandContext) Localize(key string, args ...interface{}) string {
+	if res, ok := ctx.localize(key, args); ok {
+		return res
+	}
 
-	if res != "" {
-		return ctx.Reply(res)
+	for _, lang := range ctx.localeChain() {
+		if res := lang.Get("LOCALE_NO_KEY", key); res != "" {
+			return res
+		}
 	}
+	return fmt.Sprintf("No localization found for the key \"%s\" Please report this to the developers.", key)
+}
 
-	fallback := ctx.Bot.DefaultLocale.Get(key, args...)
-	if fallback != "" {
-		return ctx.Reply(fallback)
+// LocalizeDefault is like Localize but returns def if the key isn't localized.
+func (ctx *CommandContext) LocalizeDefault(key string, def string, args ...interface{}) string {
+	if res, ok := ctx.localize(key, args); ok {
+		return res
 	}
+	return def
+}
 
-	return ctx.Reply(ctx.Locale.GetDefault("LOCALE_NO_KEY", key,
-		ctx.Bot.DefaultLocale.GetDefault("LOCALE_NO_KEY", key,
-			fmt.Sprintf("No localization found for the key \"%s\" Please report this to the developers.", key))))
+// FormatDuration is like Language.FormatDuration but the units are looked up through the locale's parents
+// and the default locale before English.
+func (ctx *CommandContext) FormatDuration(d time.Duration) string {
+	return formatDuration(ctx.localeChain(), d, 2)
 }
 
-// EditLocale edits msg with a localized key
-func (ctx *CommandContext) EditLocale(msg *discordgo.Message, key string, args ...interface{}) (*discordgo.Message, error) {
-	res := ctx.Locale.Get(key, args...)
-	if res != "" {
-		return ctx.Edit(msg, res)
+// FormatRelative is like Language.FormatRelative with the locale's parents and the default locale.
+func (ctx *CommandContext) FormatRelative(t time.Time) string {
+	return formatRelative(ctx.localeChain(), t, time.Now())
+}
+
+func (ctx *CommandContext) localize(key string, args []interface{}) (string, bool) {
+	partial := ""
+	found := false
+	for _, lang := range ctx.localeChain() {
+		res, err := lang.Format(key, args...)
+		if err == nil {
+			return res, true
+		}
+		if !errors.Is(err, ErrLocaleNoKey) {
+			ctx.Bot.ErrorHandler(ctx.Bot, err)
+			if !found {
+				partial = res
+				found = true
+			}
+		}
+	}
+	return partial, found
+}
+
+func (ctx *CommandContext) localeChain() []*Language {
+	if len(ctx.Locales) > 0 {
+		return ctx.Locales
 	}
-	fallback := ctx.Bot.DefaultLocale.Get(key, args...)
-	if fallback != "" {
-		return ctx.Edit(msg, fallback)
+	if ctx.Locale == nil {
+		return ctx.Bot.LocaleChain("")
 	}
+	return ctx.Bot.LocaleChain(ctx.Locale.Name)
+}
+
+// ReplyLocale sends a localized key for the current context's locale.
+func (ctx *CommandContext) ReplyLocale(key string, args ...interface{}) (*discordgo.Message, error) {
+	return ctx.Reply(ctx.Localize(key, ctx.sanitizeArgs(args)...))
+}
 
-	return ctx.Edit(msg, ctx.Locale.GetDefault("LOCALE_NO_KEY", key,
-		ctx.Bot.DefaultLocale.GetDefault("LOCALE_NO_KEY", key,
-			fmt.Sprintf("No localization found for the key \"%s\" Please report this to the developers.", key))))
+// EditLocale edits msg with a localized key
+func (ctx *CommandContext) EditLocale(msg *discordgo.Message, key string, args ...interface{}) (*discordgo.Message, error) {
+	return ctx.Edit(msg, ctx.Localize(key, args...))
 }
 
 // Edit edits msg's content
@@ -233,31 +422,56 @@ func (ctx *CommandContext) HasArgs() bool {
 
 // ReplyEmbed replies with an embed.
 func (ctx *CommandContext) ReplyEmbed(embed *discordgo.MessageEmbed) (*discordgo.Message, error) {
-	if !ctx.Command.Editable {
-		return ctx.ReplyEmbedNoEdit(embed)
-	}
-	m, ok := ctx.Bot.CommandEdits[ctx.Message.ID]
-	if !ok {
-		msg, err := ctx.Session.ChannelMessageSendEmbed(ctx.Channel.ID, embed)
-		if err != nil {
-			return nil, err
-		}
-		ctx.Bot.CommandEdits[ctx.Message.ID] = msg.ID
-		return msg, nil
-	}
-	return ctx.Session.ChannelMessageEditComplex(discordgo.NewMessageEdit(ctx.Channel.ID, m).SetContent("").SetEmbed(embed))
+	return ctx.Send(MessageOptions{Embed: embed})
 }
 
 // ReplyEmbedNoEdits replies with an embed but not considering the editable option of the command.
 func (ctx *CommandContext) ReplyEmbedNoEdit(embed *discordgo.MessageEmbed) (*discordgo.Message, error) {
-	return ctx.Session.ChannelMessageSendEmbed(ctx.Channel.ID, embed)
+	return ctx.Send(MessageOptions{Embed: embed, NoEdit: true})
 }
 
 // BuildEmbed calls ReplyEmbed(embed.Build())
+// If the embed overflows and its Overflow is set it is split, see ReplySplitEmbed.
 func (ctx *CommandContext) BuildEmbed(embed *Embed) (*discordgo.Message, error) {
+	if embed.Overflow != OverflowTruncate && embed.Overflows() {
+		return ctx.ReplySplitEmbed(embed, embed.Overflow)
+	}
 	return ctx.ReplyEmbed(embed.Build())
 }
 
+// ReplySplitEmbed splits the embed with embed.Split() and replies with the parts.
+// With OverflowMessages each part is sent in its own message and the first one is returned,
+// with OverflowPages the parts are shown in a paginator running in the background and the message of its first page is returned.
+// The paginator shows the embed's footer next to the page number, the parts keep room for it.
+func (ctx *CommandContext) ReplySplitEmbed(embed *Embed, overflow EmbedOverflow) (*discordgo.Message, error) {
+	var parts []*discordgo.MessageEmbed
+	if overflow == OverflowPages {
+		p := NewPaginatorForContext(ctx)
+		if embed.Footer != nil {
+			p.SetExtra(embed.Footer.Text)
+		}
+		p.AddSplitPages(embed)
+		if len(p.Pages) > 1 {
+			msg, _, err := p.Start()
+			return msg, err
+		}
+		parts = p.Pages
+	} else {
+		parts = embed.Split()
+	}
+
+	first, err := ctx.ReplyEmbed(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts[1:] {
+		if _, err := ctx.ReplyEmbedNoEdit(part); err != nil {
+			return first, err
+		}
+	}
+	return first, nil
+}
+
 // BuildEmbedNoEdit calls ReplyEmbedNoEdit(embed.Build())
 func (ctx *CommandContext) BuildEmbedNoEdit(embed *Embed) (*discordgo.Message, error) {
 	return ctx.ReplyEmbedNoEdit(embed.Build())
@@ -269,7 +483,7 @@ func (ctx *CommandContext) SendFile(name string, file io.Reader, content string,
 		content = fmt.Sprintf(content, args...)
 	}
 
-	return ctx.Session.ChannelFileSendWithMessage(ctx.Channel.ID, content, name, file)
+	return ctx.Send(MessageOptions{Content: content, Files: []*discordgo.File{{Name: name, Reader: file}}})
 }
 
 func (ctx *CommandContext) Error(err interface{}, args ...interface{}) {
@@ -328,7 +542,7 @@ func (ctx *CommandContext) ParseArgs() bool {
 		v := safeGet(i)
 
 		if tag.Required && v == "" {
-			ctx.Reply("The argument **%s** is required.", tag.Name)
+			ctx.ReplyLocale("ARGUMENT_REQUIRED", LocaleArgs{"name": tag.Name})
 			return false
 		}
 
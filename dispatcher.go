@@ -0,0 +1,238 @@
+package gocto
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrTaskDropped is passed to the ErrorHandler when the bot's dispatcher drops a task.
+var ErrTaskDropped = errors.New("dispatcher dropped a task")
+
+// DropPolicy decides what happens to a task when the dispatcher's queue is full.
+type DropPolicy int
+
+const (
+	DropNewest DropPolicy = iota // Drops the incoming task.
+	DropOldest                   // Drops the oldest task of the incoming task's queue to make room for it, or the incoming one if that queue is empty.
+	DropBlock                    // Waits until there is room in the queue, tasks still waiting when the dispatcher stops are dropped.
+)
+
+// DispatchOrder decides which tasks are guaranteed to run in the order they were submitted.
+type DispatchOrder int
+
+const (
+	OrderNone    DispatchOrder = iota // No ordering, tasks go to the least busy worker.
+	OrderGuild                        // Tasks of the same guild run one after another in order.
+	OrderChannel                      // Tasks of the same channel run one after another in order.
+)
+
+// DispatcherStats is a snapshot of a dispatcher's queue metrics.
+type DispatcherStats struct {
+	Workers   int    // Number of workers.
+	QueueSize int    // Maximum number of queued tasks.
+	Queued    int    // Tasks waiting for a worker.
+	Running   int    // Tasks being ran.
+	Processed uint64 // Tasks that finished running.
+	Dropped   uint64 // Tasks dropped because the queue was full.
+}
+
+// Dispatcher runs tasks on a bounded pool of workers.
+// Set one with bot.SetDispatcher to run asynchronous monitors (including the command handler) on it
+// instead of starting a goroutine for each of them.
+type Dispatcher struct {
+	processed    uint64 // Atomically accessed 64-bit fields go first to be aligned on 32-bit platforms.
+	dropped      uint64
+	Policy       DropPolicy        // What to do when the queue is full. (default: DropNewest)
+	Order        DispatchOrder     // Which tasks keep their order. (default: OrderNone)
+	PanicHandler func(interface{}) // Called with panics recovered from tasks, bot.SetDispatcher sets it to the ErrorHandler.
+	queues       []chan func()     // The queue of each worker, for tasks with an ordering key.
+	shared       chan func()       // The queue any worker takes tasks from, for tasks without an ordering key.
+	slots        chan struct{}     // Holds a value for each queued task, bounding all the queues to queueSize together.
+	queueSize    int
+	running      int32
+	wg           sync.WaitGroup
+	stopLock     sync.RWMutex
+	stopped      bool
+	stop         chan struct{}
+}
+
+// NewDispatcher creates a dispatcher with workers goroutines and room for queueSize waiting tasks and starts it.
+func NewDispatcher(workers, queueSize int) *Dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	// Any queue can hold every task, slots keeps the total under queueSize.
+	d := &Dispatcher{
+		Policy:    DropNewest,
+		Order:     OrderNone,
+		queues:    make([]chan func(), workers),
+		shared:    make(chan func(), queueSize),
+		slots:     make(chan struct{}, queueSize),
+		queueSize: queueSize,
+		stop:      make(chan struct{}),
+	}
+	for i := range d.queues {
+		d.queues[i] = make(chan func(), queueSize)
+		d.wg.Add(1)
+		go d.worker(d.queues[i], d.shared)
+	}
+	return d
+}
+
+func (d *Dispatcher) SetPolicy(policy DropPolicy) *Dispatcher {
+	d.Policy = policy
+	return d
+}
+
+func (d *Dispatcher) SetOrder(order DispatchOrder) *Dispatcher {
+	d.Order = order
+	return d
+}
+
+// worker runs the tasks of its own queue and of the shared queue until both are closed and empty.
+func (d *Dispatcher) worker(queue, shared chan func()) {
+	defer d.wg.Done()
+	for queue != nil || shared != nil {
+		select {
+		case task, ok := <-queue:
+			if !ok {
+				queue = nil
+				continue
+			}
+			<-d.slots
+			d.run(task)
+		case task, ok := <-shared:
+			if !ok {
+				shared = nil
+				continue
+			}
+			<-d.slots
+			d.run(task)
+		}
+	}
+}
+
+func (d *Dispatcher) run(task func()) {
+	atomic.AddInt32(&d.running, 1)
+	defer func() {
+		atomic.AddInt32(&d.running, -1)
+		atomic.AddUint64(&d.processed, 1)
+		if err := recover(); err != nil && d.PanicHandler != nil {
+			d.PanicHandler(err)
+		}
+	}()
+	task()
+}
+
+// queueFor picks the queue of a task, tasks with the same ordering key always use the same queue
+// and the others go to the shared queue so they run on the first idle worker.
+func (d *Dispatcher) queueFor(guildID, channelID int64) chan func() {
+	var key int64
+	switch d.Order {
+	case OrderGuild:
+		key = guildID
+	case OrderChannel:
+		key = channelID
+	}
+
+	if key != 0 {
+		return d.queues[uint64(key)%uint64(len(d.queues))]
+	}
+	return d.shared
+}
+
+// Submit queues the task, guildID and channelID are used for ordering and can be 0 if unknown.
+// Returns false if the task was dropped, tasks submitted after Stop are dropped.
+func (d *Dispatcher) Submit(guildID, channelID int64, task func()) bool {
+	queue := d.queueFor(guildID, channelID)
+	switch d.Policy {
+	case DropBlock:
+		// Wait without holding stopLock so Stop isn't blocked by a full queue.
+		select {
+		case d.slots <- struct{}{}:
+		case <-d.stop:
+			atomic.AddUint64(&d.dropped, 1)
+			return false
+		}
+	case DropOldest:
+		for !d.acquire() {
+			select {
+			case _, ok := <-queue:
+				if !ok {
+					// Stopped.
+					atomic.AddUint64(&d.dropped, 1)
+					return false
+				}
+				<-d.slots
+				atomic.AddUint64(&d.dropped, 1)
+			default:
+				// The other queues hold the tasks, drop the incoming one.
+				atomic.AddUint64(&d.dropped, 1)
+				return false
+			}
+		}
+	default:
+		if !d.acquire() {
+			atomic.AddUint64(&d.dropped, 1)
+			return false
+		}
+	}
+	return d.push(queue, task)
+}
+
+// acquire takes a slot for a task, returns false if the queue is full.
+func (d *Dispatcher) acquire() bool {
+	select {
+	case d.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// push queues the task after its slot was acquired, the queues are as large as all of them together
+// so this never blocks.
+func (d *Dispatcher) push(queue chan func(), task func()) bool {
+	d.stopLock.RLock()
+	defer d.stopLock.RUnlock()
+	if d.stopped {
+		<-d.slots
+		atomic.AddUint64(&d.dropped, 1)
+		return false
+	}
+	queue <- task
+	return true
+}
+
+// Stats returns the current queue metrics.
+func (d *Dispatcher) Stats() DispatcherStats {
+	queued := len(d.slots)
+	return DispatcherStats{
+		Workers:   len(d.queues),
+		QueueSize: d.queueSize,
+		Queued:    queued,
+		Running:   int(atomic.LoadInt32(&d.running)),
+		Processed: atomic.LoadUint64(&d.processed),
+		Dropped:   atomic.LoadUint64(&d.dropped),
+	}
+}
+
+// Stop stops accepting tasks and waits for the queued ones to finish.
+func (d *Dispatcher) Stop() {
+	d.stopLock.Lock()
+	if !d.stopped {
+		d.stopped = true
+		close(d.stop)
+		for _, q := range d.queues {
+			close(q)
+		}
+		close(d.shared)
+	}
+	d.stopLock.Unlock()
+	d.wg.Wait()
+}
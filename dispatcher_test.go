@@ -0,0 +1,150 @@
+package gocto
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDispatcherOrder(t *testing.T) {
+	d := NewDispatcher(4, 400).SetPolicy(DropBlock).SetOrder(OrderChannel)
+	var lock sync.Mutex
+	results := make(map[int64][]int)
+	for i := 0; i < 100; i++ {
+		i := i
+		channel := int64(i%3 + 1)
+		d.Submit(0, channel, func() {
+			lock.Lock()
+			results[channel] = append(results[channel], i)
+			lock.Unlock()
+		})
+	}
+	d.Stop()
+
+	for channel, res := range results {
+		for i := 1; i < len(res); i++ {
+			if res[i] < res[i-1] {
+				t.Errorf("Tasks of channel %d ran out of order: %v", channel, res)
+				break
+			}
+		}
+	}
+	if stats := d.Stats(); stats.Processed != 100 {
+		t.Errorf("Expected 100 processed tasks but got %d", stats.Processed)
+	}
+}
+
+func TestDispatcherDrop(t *testing.T) {
+	d := NewDispatcher(1, 1)
+	block := make(chan bool)
+	started := make(chan bool)
+	d.Submit(0, 0, func() {
+		started <- true
+		<-block
+	})
+	<-started
+
+	if !d.Submit(0, 0, func() {}) {
+		t.Error("Expected the first queued task to be accepted")
+	}
+	if d.Submit(0, 0, func() {}) {
+		t.Error("Expected a task to be dropped when the queue is full")
+	}
+
+	d.SetPolicy(DropOldest)
+	if !d.Submit(0, 0, func() {}) {
+		t.Error("Expected DropOldest to accept the new task")
+	}
+
+	stats := d.Stats()
+	if stats.Dropped != 2 || stats.Queued != 1 || stats.Running != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	close(block)
+	d.Stop()
+}
+
+func TestDispatcherIdleWorkers(t *testing.T) {
+	d := NewDispatcher(4, 8)
+	block := make(chan bool)
+	started := make(chan bool)
+	d.Submit(0, 0, func() {
+		started <- true
+		<-block
+	})
+	<-started
+
+	done := make(chan bool, 1)
+	d.Submit(0, 0, func() { done <- true })
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Expected an idle worker to run the task while another one is blocked")
+	}
+	close(block)
+	d.Stop()
+
+	if d.Submit(0, 0, func() {}) {
+		t.Error("Expected tasks submitted after Stop to be dropped")
+	}
+}
+
+func TestDispatcherBlockStop(t *testing.T) {
+	d := NewDispatcher(1, 1).SetPolicy(DropBlock)
+	block := make(chan bool)
+	started := make(chan bool)
+	d.Submit(0, 0, func() {
+		started <- true
+		<-block
+	})
+	<-started
+	d.Submit(0, 0, func() {})
+
+	dropped := make(chan bool)
+	go func() {
+		dropped <- !d.Submit(0, 0, func() {})
+	}()
+	stopped := make(chan bool)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		d.Stop()
+		stopped <- true
+	}()
+
+	select {
+	case ok := <-dropped:
+		if !ok {
+			t.Error("Expected the blocked task to be dropped on Stop")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected Stop to release the blocked Submit")
+	}
+	close(block)
+	<-stopped
+}
+
+func TestDispatcherQueueSize(t *testing.T) {
+	d := NewDispatcher(4, 10).SetOrder(OrderChannel)
+	block := make(chan bool)
+	var started sync.WaitGroup
+	started.Add(4)
+	for i := int64(0); i < 4; i++ {
+		d.Submit(0, i+1, func() {
+			started.Done()
+			<-block
+		})
+	}
+	started.Wait()
+
+	accepted := 0
+	for i := int64(0); i < 20; i++ {
+		if d.Submit(0, i, func() {}) {
+			accepted++
+		}
+	}
+	if stats := d.Stats(); accepted != 10 || stats.QueueSize != 10 || stats.Queued != 10 {
+		t.Errorf("Expected 10 queued tasks, accepted %d with stats %+v", accepted, stats)
+	}
+	close(block)
+	d.Stop()
+}
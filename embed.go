@@ -2,14 +2,28 @@ package gocto
 
 import (
 	"fmt"
+	"github.com/Noctember/gocto/helpers"
 	"github.com/jonas747/discordgo"
+	"unicode/utf8"
 )
 
 // Embed ...
 type Embed struct {
 	*discordgo.MessageEmbed
+	Ellipsis     string        // Appended to truncated texts, it counts towards the limits. (default: none)
+	EnforceLimit bool          // Wether Build truncates the embed to fit in EmbedLimit. (default: false)
+	Overflow     EmbedOverflow // What ctx.BuildEmbed does with an embed over the limits. (default: OverflowTruncate)
 }
 
+// EmbedOverflow is what ctx.BuildEmbed does with an embed that has too many fields or is too long.
+type EmbedOverflow int
+
+const (
+	OverflowTruncate EmbedOverflow = iota // Sends it as is, leaving the truncation to EnforceLimit.
+	OverflowMessages                      // Splits it into several messages.
+	OverflowPages                         // Splits it into the pages of a paginator.
+)
+
 const (
 	EmbedLimitTitle       = 256
 	EmbedLimitDescription = 2048
@@ -17,73 +31,94 @@ const (
 	EmbedLimitFieldName   = 256
 	EmbedLimitField       = 25
 	EmbedLimitFooter      = 2048
-	EmbedLimit            = 4000
+	EmbedLimitAuthorName  = 256
+	EmbedLimit            = 6000 // The limit of all texts combined.
 )
 
 func NewEmbed() *Embed {
-	return &Embed{&discordgo.MessageEmbed{}}
+	return &Embed{
+		MessageEmbed: &discordgo.MessageEmbed{},
+		Ellipsis:     "",
+		EnforceLimit: false,
+		Overflow:     OverflowTruncate,
+	}
 }
 
+// Build returns the discordgo embed, truncating it to fit in EmbedLimit first if EnforceLimit is set.
 func (e *Embed) Build() *discordgo.MessageEmbed {
+	if e.EnforceLimit {
+		e.TruncateTotal()
+	}
 	return e.MessageEmbed
 }
 
-func (e *Embed) SetTitle(name string) *Embed {
-	e.Title = name
+// SetEllipsis sets the text appended to truncated texts e.g "…"
+func (e *Embed) SetEllipsis(ellipsis string) *Embed {
+	e.Ellipsis = ellipsis
 	return e
 }
 
-func (e *Embed) SetDescription(description string) *Embed {
-	if len(description) > 2048 {
-		description = description[:2048]
-	}
-	e.Description = description
+// SetOverflow sets what ctx.BuildEmbed does with the embed if it has too many fields or is too long.
+func (e *Embed) SetOverflow(overflow EmbedOverflow) *Embed {
+	e.Overflow = overflow
 	return e
 }
 
-func (e *Embed) AddField(name, value string, args ...interface{}) *Embed {
-	if len(value) > 1024 {
-		value = value[:1024]
-	}
+// SetEnforceLimit sets wether Build truncates the embed to fit in EmbedLimit.
+func (e *Embed) SetEnforceLimit(toggle bool) *Embed {
+	e.EnforceLimit = toggle
+	return e
+}
 
-	if len(name) > 1024 {
-		name = name[:1024]
+// truncate cuts s to at most limit characters, counting runes rather than bytes so multi-byte characters aren't cut in half.
+func (e *Embed) truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
 	}
-
-	if len(args) > 0 {
-		value = fmt.Sprintf(value, args...)
+	ellipsis := e.Ellipsis
+	if utf8.RuneCountInString(ellipsis) > limit {
+		ellipsis = ""
 	}
+	limit -= utf8.RuneCountInString(ellipsis)
+	i := 0
+	for index := range s {
+		if i == limit {
+			return s[:index] + ellipsis
+		}
+		i++
+	}
+	return s
+}
 
-	e.Fields = append(e.Fields, &discordgo.MessageEmbedField{
-		Name:  name,
-		Value: value,
-	})
-
+func (e *Embed) SetTitle(name string) *Embed {
+	e.Title = name
 	return e
 }
 
-func (e *Embed) AddInlineField(name, value string, args ...interface{}) *Embed {
-	if len(value) > 1024 {
-		value = value[:1024]
-	}
-
-	if len(name) > 1024 {
-		name = name[:1024]
-	}
+func (e *Embed) SetDescription(description string) *Embed {
+	e.Description = e.truncate(description, EmbedLimitDescription)
+	return e
+}
 
+func (e *Embed) AddField(name, value string, args ...interface{}) *Embed {
 	if len(args) > 0 {
 		value = fmt.Sprintf(value, args...)
 	}
 
 	e.Fields = append(e.Fields, &discordgo.MessageEmbedField{
-		Name:   name,
-		Value:  value,
-		Inline: true,
+		Name:  e.truncate(name, EmbedLimitFieldName),
+		Value: e.truncate(value, EmbedLimitFieldValue),
 	})
 
 	return e
 }
 
+func (e *Embed) AddInlineField(name, value string, args ...interface{}) *Embed {
+	e.AddField(name, value, args...)
+	e.Fields[len(e.Fields)-1].Inline = true
+	return e
+}
+
 func (e *Embed) SetFooter(args ...string) *Embed {
 	iconURL := ""
 	text := ""
@@ -216,45 +251,187 @@ func (e *Embed) Truncate() *Embed {
 	e.TruncateFields()
 	e.TruncateFooter()
 	e.TruncateTitle()
+	e.TruncateAuthor()
 	return e
 }
 
 func (e *Embed) TruncateFields() *Embed {
-	if len(e.Fields) > 25 {
+	if len(e.Fields) > EmbedLimitField {
 		e.Fields = e.Fields[:EmbedLimitField]
 	}
 
 	for _, v := range e.Fields {
+		v.Name = e.truncate(v.Name, EmbedLimitFieldName)
+		v.Value = e.truncate(v.Value, EmbedLimitFieldValue)
+	}
+	return e
+}
 
-		if len(v.Name) > EmbedLimitFieldName {
-			v.Name = v.Name[:EmbedLimitFieldName]
-		}
+func (e *Embed) TruncateDescription() *Embed {
+	e.Description = e.truncate(e.Description, EmbedLimitDescription)
+	return e
+}
 
-		if len(v.Value) > EmbedLimitFieldValue {
-			v.Value = v.Value[:EmbedLimitFieldValue]
-		}
+func (e *Embed) TruncateTitle() *Embed {
+	e.Title = e.truncate(e.Title, EmbedLimitTitle)
+	return e
+}
 
+func (e *Embed) TruncateFooter() *Embed {
+	if e.Footer != nil {
+		e.Footer.Text = e.truncate(e.Footer.Text, EmbedLimitFooter)
 	}
 	return e
 }
 
-func (e *Embed) TruncateDescription() *Embed {
-	if len(e.Description) > EmbedLimitDescription {
-		e.Description = e.Description[:EmbedLimitDescription]
+func (e *Embed) TruncateAuthor() *Embed {
+	if e.Author != nil {
+		e.Author.Name = e.truncate(e.Author.Name, EmbedLimitAuthorName)
 	}
 	return e
 }
 
-func (e *Embed) TruncateTitle() *Embed {
-	if len(e.Title) > EmbedLimitTitle {
-		e.Title = e.Title[:EmbedLimitTitle]
+// TruncateTotal truncates the embed to its limits and then to fit in EmbedLimit,
+// dropping fields from the end and then shortening the description.
+func (e *Embed) TruncateTotal() *Embed {
+	e.Truncate()
+	for len(e.Fields) > 0 && e.Length() > EmbedLimit {
+		e.Fields = e.Fields[:len(e.Fields)-1]
+	}
+	if over := e.Length() - EmbedLimit; over > 0 {
+		limit := utf8.RuneCountInString(e.Description) - over
+		if limit < 0 {
+			limit = 0
+		}
+		e.Description = e.truncate(e.Description, limit)
 	}
 	return e
 }
 
-func (e *Embed) TruncateFooter() *Embed {
-	if e.Footer != nil && len(e.Footer.Text) > EmbedLimitFooter {
-		e.Footer.Text = e.Footer.Text[:EmbedLimitFooter]
+// Length returns the number of characters counted towards EmbedLimit.
+func (e *Embed) Length() int {
+	return embedLength(e.MessageEmbed)
+}
+
+func embedLength(e *discordgo.MessageEmbed) int {
+	length := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	for _, v := range e.Fields {
+		length += fieldLength(v)
 	}
-	return e
+	if e.Footer != nil {
+		length += utf8.RuneCountInString(e.Footer.Text)
+	}
+	if e.Author != nil {
+		length += utf8.RuneCountInString(e.Author.Name)
+	}
+	return length
+}
+
+func fieldLength(field *discordgo.MessageEmbedField) int {
+	return utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+}
+
+// Overflows returns true if the embed has too many fields, a too long description or goes over EmbedLimit.
+func (e *Embed) Overflows() bool {
+	return len(e.Fields) > EmbedLimitField ||
+		utf8.RuneCountInString(e.Description) > EmbedLimitDescription ||
+		e.Length() > EmbedLimit
+}
+
+// Split splits the embed into parts that fit in the limits, the title, URL, author and thumbnail stay on the first part
+// and the footer, image and timestamp on the last one. A long description is split on line boundaries.
+// Texts over their own limits are truncated first.
+func (e *Embed) Split() []*discordgo.MessageEmbed {
+	return e.SplitReserved(0)
+}
+
+// SplitReserved is like Split but every part keeps room for a footer of reserved characters,
+// for a footer set on each part after splitting e.g by a paginator.
+func (e *Embed) SplitReserved(reserved int) []*discordgo.MessageEmbed {
+	e.TruncateTitle()
+	e.TruncateFooter()
+	e.TruncateAuthor()
+	for _, v := range e.Fields {
+		v.Name = e.truncate(v.Name, EmbedLimitFieldName)
+		v.Value = e.truncate(v.Value, EmbedLimitFieldValue)
+	}
+
+	// Every part keeps room for the footer as the last part isn't known in advance.
+	if e.Footer != nil && utf8.RuneCountInString(e.Footer.Text) > reserved {
+		reserved = utf8.RuneCountInString(e.Footer.Text)
+	}
+
+	part := &discordgo.MessageEmbed{
+		Type:      e.Type,
+		Color:     e.Color,
+		Title:     e.Title,
+		URL:       e.URL,
+		Author:    e.Author,
+		Thumbnail: e.Thumbnail,
+	}
+	var parts []*discordgo.MessageEmbed
+	next := func() {
+		parts = append(parts, part)
+		part = &discordgo.MessageEmbed{Type: e.Type, Color: e.Color}
+	}
+
+	for i, description := range helpers.SplitText(e.Description, helpers.SplitOptions{MaxLength: EmbedLimitDescription}) {
+		if i > 0 {
+			next()
+		}
+		part.Description = description
+	}
+
+	for _, field := range e.Fields {
+		if len(part.Fields) == EmbedLimitField || embedLength(part)+fieldLength(field)+reserved > EmbedLimit {
+			next()
+		}
+		part.Fields = append(part.Fields, field)
+	}
+
+	part.Footer = e.Footer
+	part.Image = e.Image
+	part.Timestamp = e.Timestamp
+	return append(parts, part)
+}
+
+// EmbedLimitError is a limit an embed goes over.
+type EmbedLimitError struct {
+	Field  string // What is over the limit e.g "title" or "fields[2].value"
+	Length int
+	Limit  int
+}
+
+func (err *EmbedLimitError) Error() string {
+	return fmt.Sprintf("embed %s is %d long but the limit is %d", err.Field, err.Length, err.Limit)
+}
+
+// Validate returns every limit the embed goes over, nil if it is within the limits.
+func (e *Embed) Validate() []*EmbedLimitError {
+	var errs []*EmbedLimitError
+	check := func(field, text string, limit int) {
+		if length := utf8.RuneCountInString(text); length > limit {
+			errs = append(errs, &EmbedLimitError{Field: field, Length: length, Limit: limit})
+		}
+	}
+
+	check("title", e.Title, EmbedLimitTitle)
+	check("description", e.Description, EmbedLimitDescription)
+	if len(e.Fields) > EmbedLimitField {
+		errs = append(errs, &EmbedLimitError{Field: "fields", Length: len(e.Fields), Limit: EmbedLimitField})
+	}
+	for i, v := range e.Fields {
+		check(fmt.Sprintf("fields[%d].name", i), v.Name, EmbedLimitFieldName)
+		check(fmt.Sprintf("fields[%d].value", i), v.Value, EmbedLimitFieldValue)
+	}
+	if e.Footer != nil {
+		check("footer", e.Footer.Text, EmbedLimitFooter)
+	}
+	if e.Author != nil {
+		check("author", e.Author.Name, EmbedLimitAuthorName)
+	}
+	if length := e.Length(); length > EmbedLimit {
+		errs = append(errs, &EmbedLimitError{Field: "total", Length: length, Limit: EmbedLimit})
+	}
+	return errs
 }
@@ -0,0 +1,75 @@
+package gocto
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEmbedTruncate(t *testing.T) {
+	em := NewEmbed().SetDescription(strings.Repeat("é", EmbedLimitDescription+10))
+	if !utf8.ValidString(em.Description) || utf8.RuneCountInString(em.Description) != EmbedLimitDescription {
+		t.Errorf("Expected %d valid runes but got %d", EmbedLimitDescription, utf8.RuneCountInString(em.Description))
+	}
+
+	em = NewEmbed().SetEllipsis("…").AddField(strings.Repeat("🎉", 300), "value")
+	name := em.Fields[0].Name
+	if utf8.RuneCountInString(name) != EmbedLimitFieldName || !strings.HasSuffix(name, "…") || !utf8.ValidString(name) {
+		t.Errorf("Unexpected truncated field name %q", name)
+	}
+}
+
+func TestEmbedValidate(t *testing.T) {
+	em := NewEmbed().SetTitle(strings.Repeat("a", EmbedLimitTitle+1))
+	for i := 0; i < EmbedLimitField+1; i++ {
+		em.AddField("name", strings.Repeat("b", EmbedLimitFieldValue))
+	}
+
+	fields := []string{}
+	for _, err := range em.Validate() {
+		fields = append(fields, err.Field)
+	}
+	if strings.Join(fields, ",") != "title,fields,total" {
+		t.Errorf("Expected title, fields and total errors but got %v", fields)
+	}
+
+	em.SetEnforceLimit(true).Build()
+	if errs := em.Validate(); len(errs) != 0 {
+		t.Errorf("Expected no errors after enforcing the limit but got %v", errs)
+	}
+	if em.Length() > EmbedLimit {
+		t.Errorf("Expected the embed to fit in %d but it is %d long", EmbedLimit, em.Length())
+	}
+}
+
+func TestEmbedSplit(t *testing.T) {
+	em := NewEmbed().SetTitle("title").SetFooter("footer").SetColor(1)
+	em.Description = strings.Repeat("line\n", 500)
+	for i := 0; i < 30; i++ {
+		em.AddField("name", strings.Repeat("v", 500))
+	}
+	if !em.Overflows() {
+		t.Fatal("Expected the embed to overflow")
+	}
+
+	parts := em.Split()
+	fields := 0
+	for i, part := range parts {
+		fields += len(part.Fields)
+		if embedLength(part) > EmbedLimit || len(part.Fields) > EmbedLimitField {
+			t.Errorf("Part %d is over the limits", i)
+		}
+		if part.Color != 1 {
+			t.Errorf("Part %d doesn't have the color", i)
+		}
+		if (part.Title != "") != (i == 0) {
+			t.Errorf("Expected only the first part to have the title, part %d has %q", i, part.Title)
+		}
+		if (part.Footer != nil) != (i == len(parts)-1) {
+			t.Errorf("Expected only the last part to have the footer, part %d has %v", i, part.Footer)
+		}
+	}
+	if fields != 30 {
+		t.Errorf("Expected 30 fields across the parts but got %d", fields)
+	}
+}
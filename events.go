@@ -0,0 +1,308 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"sort"
+	"sync/atomic"
+)
+
+// Events that event monitors can listen to.
+const (
+	EventReactionAdd    = "MESSAGE_REACTION_ADD"
+	EventReactionRemove = "MESSAGE_REACTION_REMOVE"
+	EventMemberJoin     = "GUILD_MEMBER_ADD"
+	EventMemberLeave    = "GUILD_MEMBER_REMOVE"
+	EventMessageDelete  = "MESSAGE_DELETE"
+	EventGuildCreate    = "GUILD_CREATE"
+)
+
+// EventMonitor is like Monitor but for gateway events other than messages being sent or edited.
+// Create them with the constructor of the event e.g NewReactionAddMonitor.
+type EventMonitor struct {
+	Name       string
+	Event      string // The event this monitor listens to, one of the Event* constants.
+	Enabled    bool
+	GuildOnly  bool
+	IgnoreBots bool  // Ignores events triggered by bots, when the event has a user.
+	IgnoreSelf bool  // Ignores events triggered by the bot itself, when the event has a user.
+	Priority   int   // Monitors with a higher priority run first. (default: 0)
+	Async      bool  // Wether to run in a separate goroutine. (default: true)
+	MaxPanics  int32 // Disables the monitor after panicking this many times in a row, 0 to never disable it. (default: 0)
+	panics     int32
+	run        func(ctx *EventContext, event interface{})
+}
+
+func newEventMonitor(name, event string, run func(ctx *EventContext, event interface{})) *EventMonitor {
+	return &EventMonitor{
+		Name:       name,
+		Event:      event,
+		Enabled:    true,
+		GuildOnly:  false,
+		IgnoreBots: true,
+		IgnoreSelf: true,
+		Priority:   0,
+		Async:      true,
+		run:        run,
+	}
+}
+
+func (m *EventMonitor) AllowBots() *EventMonitor {
+	m.IgnoreBots = false
+	return m
+}
+
+func (m *EventMonitor) AllowSelf() *EventMonitor {
+	m.IgnoreSelf = false
+	return m
+}
+
+func (m *EventMonitor) SetGuildOnly(toggle bool) *EventMonitor {
+	m.GuildOnly = toggle
+	return m
+}
+
+func (m *EventMonitor) SetPriority(priority int) *EventMonitor {
+	m.Priority = priority
+	return m
+}
+
+func (m *EventMonitor) SetAsync(toggle bool) *EventMonitor {
+	m.Async = toggle
+	return m
+}
+
+// SetMaxPanics disables the monitor after it panics max times in a row, 0 to never disable it.
+func (m *EventMonitor) SetMaxPanics(max int32) *EventMonitor {
+	m.MaxPanics = max
+	return m
+}
+
+// EventContext is the part of the context shared by every event.
+type EventContext struct {
+	Session *discordgo.Session
+	Bot     *Bot
+	Monitor *EventMonitor
+	Guild   *discordgo.Guild // The guild of the event, nil if it isn't in a guild or not in the state.
+	UserID  int64            // The user that triggered the event, 0 if the event has none.
+	stopped *int32
+}
+
+// StopPropagation stops the event monitors with a lower priority from running on this event.
+func (ctx *EventContext) StopPropagation() {
+	atomic.StoreInt32(ctx.stopped, 1)
+}
+
+// IsPropagationStopped returns true if a monitor called StopPropagation.
+func (ctx *EventContext) IsPropagationStopped() bool {
+	return atomic.LoadInt32(ctx.stopped) == 1
+}
+
+// ----- Reactions -----
+
+type ReactionContext struct {
+	*EventContext
+	Reaction *discordgo.MessageReaction
+	Added    bool // True for EventReactionAdd and false for EventReactionRemove.
+}
+
+type ReactionMonitorHandler func(bot *Bot, ctx *ReactionContext)
+
+func NewReactionAddMonitor(name string, monitor ReactionMonitorHandler) *EventMonitor {
+	return newEventMonitor(name, EventReactionAdd, func(ctx *EventContext, event interface{}) {
+		monitor(ctx.Bot, &ReactionContext{EventContext: ctx, Reaction: event.(*discordgo.MessageReactionAdd).MessageReaction, Added: true})
+	})
+}
+
+func NewReactionRemoveMonitor(name string, monitor ReactionMonitorHandler) *EventMonitor {
+	return newEventMonitor(name, EventReactionRemove, func(ctx *EventContext, event interface{}) {
+		monitor(ctx.Bot, &ReactionContext{EventContext: ctx, Reaction: event.(*discordgo.MessageReactionRemove).MessageReaction, Added: false})
+	})
+}
+
+// ----- Members -----
+
+type MemberContext struct {
+	*EventContext
+	Member *discordgo.Member
+	Joined bool // True for EventMemberJoin and false for EventMemberLeave.
+}
+
+type MemberMonitorHandler func(bot *Bot, ctx *MemberContext)
+
+func NewMemberJoinMonitor(name string, monitor MemberMonitorHandler) *EventMonitor {
+	return newEventMonitor(name, EventMemberJoin, func(ctx *EventContext, event interface{}) {
+		monitor(ctx.Bot, &MemberContext{EventContext: ctx, Member: event.(*discordgo.GuildMemberAdd).Member, Joined: true})
+	})
+}
+
+func NewMemberLeaveMonitor(name string, monitor MemberMonitorHandler) *EventMonitor {
+	return newEventMonitor(name, EventMemberLeave, func(ctx *EventContext, event interface{}) {
+		monitor(ctx.Bot, &MemberContext{EventContext: ctx, Member: event.(*discordgo.GuildMemberRemove).Member, Joined: false})
+	})
+}
+
+// ----- Message delete -----
+
+type MessageDeleteContext struct {
+	*EventContext
+	Message *discordgo.Message // The deleted message, usually only the IDs are known.
+}
+
+type MessageDeleteMonitorHandler func(bot *Bot, ctx *MessageDeleteContext)
+
+func NewMessageDeleteMonitor(name string, monitor MessageDeleteMonitorHandler) *EventMonitor {
+	return newEventMonitor(name, EventMessageDelete, func(ctx *EventContext, event interface{}) {
+		monitor(ctx.Bot, &MessageDeleteContext{EventContext: ctx, Message: event.(*discordgo.MessageDelete).Message})
+	})
+}
+
+// ----- Guild create -----
+
+type GuildCreateContext struct {
+	*EventContext
+}
+
+type GuildCreateMonitorHandler func(bot *Bot, ctx *GuildCreateContext)
+
+// NewGuildCreateMonitor creates a monitor for when a guild becomes available, on startup or when the bot joins it.
+func NewGuildCreateMonitor(name string, monitor GuildCreateMonitorHandler) *EventMonitor {
+	return newEventMonitor(name, EventGuildCreate, func(ctx *EventContext, event interface{}) {
+		monitor(ctx.Bot, &GuildCreateContext{EventContext: ctx})
+	})
+}
+
+// ----- Dispatching -----
+
+// AddEventMonitor adds an event monitor, replacing the one with the same name.
+func (bot *Bot) AddEventMonitor(m *EventMonitor) *Bot {
+	bot.monitorLock.Lock()
+	defer bot.monitorLock.Unlock()
+	if old, ok := bot.EventMonitors[m.Name]; ok {
+		for i, monitor := range bot.eventOrder {
+			if monitor == old {
+				bot.eventOrder = append(bot.eventOrder[:i], bot.eventOrder[i+1:]...)
+				break
+			}
+		}
+	}
+	bot.EventMonitors[m.Name] = m
+	bot.eventOrder = append(bot.eventOrder, m)
+	return bot
+}
+
+// sortedEventMonitors returns the monitors of the event sorted by priority.
+func (bot *Bot) sortedEventMonitors(event string) []*EventMonitor {
+	bot.monitorLock.RLock()
+	monitors := make([]*EventMonitor, 0)
+	for _, m := range bot.eventOrder {
+		if m.Event == event {
+			monitors = append(monitors, m)
+		}
+	}
+	bot.monitorLock.RUnlock()
+	sort.SliceStable(monitors, func(i, j int) bool {
+		return monitors[i].Priority > monitors[j].Priority
+	})
+	return monitors
+}
+
+// isBot checks if the user is a bot using the state, unknown users are assumed to not be bots.
+func (bot *Bot) isBot(guildID, userID int64) bool {
+	if guildID == 0 || userID == 0 {
+		return false
+	}
+	member, err := bot.Session.State.Member(guildID, userID)
+	return err == nil && member.User != nil && member.User.Bot
+}
+
+// eventMonitorHandler runs the monitors of the event, userIsBot is checked against IgnoreBots.
+func eventMonitorHandler(bot *Bot, event string, guildID, channelID, userID int64, userIsBot bool, guild *discordgo.Guild, data interface{}) {
+	defer func() {
+		if err := recover(); err != nil {
+			bot.ErrorHandler(bot, err)
+		}
+	}()
+
+	if guild == nil && guildID != 0 {
+		guild, _ = bot.Session.State.Guild(guildID)
+	}
+
+	stopped := new(int32)
+	for _, monitor := range bot.sortedEventMonitors(event) {
+		if atomic.LoadInt32(stopped) == 1 {
+			return
+		}
+
+		if !bot.eventMonitorEnabled(monitor) {
+			continue
+		}
+
+		if monitor.GuildOnly && guildID == 0 {
+			continue
+		}
+
+		if monitor.IgnoreSelf && userID != 0 && bot.Session.State.User != nil && userID == bot.Session.State.User.ID {
+			continue
+		}
+
+		if monitor.IgnoreBots && userIsBot {
+			continue
+		}
+
+		ctx := &EventContext{
+			Session: bot.Session,
+			Bot:     bot,
+			Monitor: monitor,
+			Guild:   guild,
+			UserID:  userID,
+			stopped: stopped,
+		}
+
+		if monitor.Async {
+			monitor, ctx := monitor, ctx
+			bot.dispatch(guildID, channelID, func() { runEventMonitor(monitor, ctx, data) })
+		} else {
+			runEventMonitor(monitor, ctx, data)
+		}
+	}
+}
+
+// runEventMonitor runs the monitor, recovering and reporting panics as a *MonitorError like runMonitor.
+func runEventMonitor(monitor *EventMonitor, ctx *EventContext, data interface{}) {
+	merr := &MonitorError{Name: monitor.Name, Event: monitor.Event, EventContext: ctx, Data: data}
+	defer ctx.Bot.recoverMonitor(merr, &monitor.panics, monitor.MaxPanics, &monitor.Enabled)
+	monitor.run(ctx, data)
+}
+
+// eventMonitorEnabled reads monitor.Enabled under monitorLock, see monitorEnabled.
+func (bot *Bot) eventMonitorEnabled(monitor *EventMonitor) bool {
+	bot.monitorLock.RLock()
+	defer bot.monitorLock.RUnlock()
+	return monitor.Enabled
+}
+
+// addEventListeners registers the session handlers that dispatch events to the event monitors.
+func addEventListeners(bot *Bot, s *discordgo.Session) {
+	s.AddHandler(func(_ *discordgo.Session, r *discordgo.MessageReactionAdd) {
+		eventMonitorHandler(bot, EventReactionAdd, r.GuildID, r.ChannelID, r.UserID, bot.isBot(r.GuildID, r.UserID), nil, r)
+	})
+	s.AddHandler(func(_ *discordgo.Session, r *discordgo.MessageReactionRemove) {
+		eventMonitorHandler(bot, EventReactionRemove, r.GuildID, r.ChannelID, r.UserID, bot.isBot(r.GuildID, r.UserID), nil, r)
+	})
+	s.AddHandler(func(_ *discordgo.Session, m *discordgo.GuildMemberAdd) {
+		if m.User != nil {
+			eventMonitorHandler(bot, EventMemberJoin, m.GuildID, 0, m.User.ID, m.User.Bot, nil, m)
+		}
+	})
+	s.AddHandler(func(_ *discordgo.Session, m *discordgo.GuildMemberRemove) {
+		if m.User != nil {
+			eventMonitorHandler(bot, EventMemberLeave, m.GuildID, 0, m.User.ID, m.User.Bot, nil, m)
+		}
+	})
+	s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDelete) {
+		eventMonitorHandler(bot, EventMessageDelete, m.GuildID, m.ChannelID, 0, false, nil, m)
+	})
+	s.AddHandler(func(_ *discordgo.Session, g *discordgo.GuildCreate) {
+		eventMonitorHandler(bot, EventGuildCreate, g.ID, 0, 0, false, g.Guild, g)
+	})
+}
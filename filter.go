@@ -0,0 +1,174 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// MonitorFilters are declarative filters checked before a monitor is dispatched,
+// a message must pass every filter that is set for the monitor to run.
+type MonitorFilters struct {
+	Guilds          []int64                 // Only run in these guilds.
+	IgnoredGuilds   []int64                 // Never run in these guilds.
+	Channels        []int64                 // Only run in these channels.
+	IgnoredChannels []int64                 // Never run in these channels.
+	Users           []int64                 // Only run for messages of these users.
+	IgnoredUsers    []int64                 // Never run for messages of these users.
+	ChannelTypes    []discordgo.ChannelType // Only run in these types of channels.
+	Trigger         *regexp.Regexp          // Only run when the content matches.
+	Keywords        []string                // Only run when the content contains one of these words, set directly they are compiled on the first match. (case insensitive)
+	AttachmentsOnly bool                    // Only run for messages with attachments.
+	MinLength       int                     // Only run when the content has at least this many characters.
+	keywords        *regexp.Regexp
+
+	keywordsOnce sync.Once
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns true if the message in channel passes the filters.
+func (f *MonitorFilters) Match(m *discordgo.Message, channel *discordgo.Channel) bool {
+	if len(f.Guilds) > 0 && !containsID(f.Guilds, m.GuildID) {
+		return false
+	}
+	if containsID(f.IgnoredGuilds, m.GuildID) {
+		return false
+	}
+	if len(f.Channels) > 0 && !containsID(f.Channels, m.ChannelID) {
+		return false
+	}
+	if containsID(f.IgnoredChannels, m.ChannelID) {
+		return false
+	}
+	if len(f.Users) > 0 && !containsID(f.Users, m.Author.ID) {
+		return false
+	}
+	if containsID(f.IgnoredUsers, m.Author.ID) {
+		return false
+	}
+	if len(f.ChannelTypes) > 0 {
+		found := false
+		for _, typ := range f.ChannelTypes {
+			if channel.Type == typ {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.AttachmentsOnly && len(m.Attachments) == 0 {
+		return false
+	}
+	if f.MinLength > 0 && utf8.RuneCountInString(m.Content) < f.MinLength {
+		return false
+	}
+	if f.Trigger != nil && !f.Trigger.MatchString(m.Content) {
+		return false
+	}
+	if len(f.Keywords) > 0 {
+		f.keywordsOnce.Do(func() {
+			if f.keywords == nil {
+				// Keywords was set directly instead of with SetKeywords.
+				f.keywords = keywordsRegex(f.Keywords)
+			}
+		})
+		re := f.keywords
+		if re == nil {
+			// Cleared by SetKeywords after the first match.
+			re = keywordsRegex(f.Keywords)
+		}
+		if !re.MatchString(m.Content) {
+			return false
+		}
+	}
+	return true
+}
+
+// keywordsRegex matches any of the keywords as whole words, case insensitive.
+// Words are delimited by anything but unicode letters, numbers and underscores so e.g "café" isn't cut at the é.
+func keywordsRegex(keywords []string) *regexp.Regexp {
+	quoted := make([]string, len(keywords))
+	for i, k := range keywords {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	return regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}_])(?:` + strings.Join(quoted, "|") + `)(?:[^\p{L}\p{N}_]|$)`)
+}
+
+// InGuilds makes the monitor only run in the guilds.
+func (m *Monitor) InGuilds(ids ...int64) *Monitor {
+	m.Filters.Guilds = append(m.Filters.Guilds, ids...)
+	return m
+}
+
+func (m *Monitor) IgnoreGuilds(ids ...int64) *Monitor {
+	m.Filters.IgnoredGuilds = append(m.Filters.IgnoredGuilds, ids...)
+	return m
+}
+
+// InChannels makes the monitor only run in the channels.
+func (m *Monitor) InChannels(ids ...int64) *Monitor {
+	m.Filters.Channels = append(m.Filters.Channels, ids...)
+	return m
+}
+
+func (m *Monitor) IgnoreChannels(ids ...int64) *Monitor {
+	m.Filters.IgnoredChannels = append(m.Filters.IgnoredChannels, ids...)
+	return m
+}
+
+// ForUsers makes the monitor only run for messages of the users.
+func (m *Monitor) ForUsers(ids ...int64) *Monitor {
+	m.Filters.Users = append(m.Filters.Users, ids...)
+	return m
+}
+
+func (m *Monitor) IgnoreUsers(ids ...int64) *Monitor {
+	m.Filters.IgnoredUsers = append(m.Filters.IgnoredUsers, ids...)
+	return m
+}
+
+// InChannelTypes makes the monitor only run in the types of channels e.g discordgo.ChannelTypeGuildText
+func (m *Monitor) InChannelTypes(types ...discordgo.ChannelType) *Monitor {
+	m.Filters.ChannelTypes = append(m.Filters.ChannelTypes, types...)
+	return m
+}
+
+// SetTrigger makes the monitor only run when the content matches the regexp, panics if it is invalid.
+func (m *Monitor) SetTrigger(pattern string) *Monitor {
+	m.Filters.Trigger = regexp.MustCompile(pattern)
+	return m
+}
+
+// SetKeywords makes the monitor only run when the content contains one of the words, case insensitive.
+func (m *Monitor) SetKeywords(keywords ...string) *Monitor {
+	m.Filters.Keywords = keywords
+	m.Filters.keywords = nil
+	if len(keywords) > 0 {
+		m.Filters.keywords = keywordsRegex(keywords)
+	}
+	return m
+}
+
+// SetAttachmentsOnly makes the monitor only run for messages with attachments.
+func (m *Monitor) SetAttachmentsOnly(toggle bool) *Monitor {
+	m.Filters.AttachmentsOnly = toggle
+	return m
+}
+
+// SetMinLength makes the monitor only run when the content has at least length characters.
+func (m *Monitor) SetMinLength(length int) *Monitor {
+	m.Filters.MinLength = length
+	return m
+}
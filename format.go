@@ -0,0 +1,184 @@
+package gocto
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NumberSeparators maps base language tags to their decimal and thousands separators.
+// NewLanguage picks the separators from here, languages not listed use "." and ",".
+var NumberSeparators = map[string][2]string{
+	"fr": {",", "\u00a0"},
+	"de": {",", "."},
+	"es": {",", "."},
+	"it": {",", "."},
+	"nl": {",", "."},
+	"pt": {",", "."},
+	"tr": {",", "."},
+	"ru": {",", "\u00a0"},
+	"uk": {",", "\u00a0"},
+	"pl": {",", "\u00a0"},
+}
+
+func separatorsFor(name string) (string, string) {
+	if seps, ok := NumberSeparators[baseTag(name)]; ok {
+		return seps[0], seps[1]
+	}
+	return ".", ","
+}
+
+// SetSeparators sets the decimal and thousands separators used to format numbers.
+func (l *Language) SetSeparators(decimal, thousands string) *Language {
+	l.DecimalSeparator = decimal
+	l.ThousandsSeparator = thousands
+	return l
+}
+
+// localizeDigits replaces the separators of a number formatted by strconv.
+func (l *Language) localizeDigits(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign = "-"
+		s = s[1:]
+	}
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+
+	var out strings.Builder
+	out.WriteString(sign)
+	for i, c := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			out.WriteString(l.ThousandsSeparator)
+		}
+		out.WriteRune(c)
+	}
+	if fraction != "" {
+		out.WriteString(l.DecimalSeparator)
+		out.WriteString(fraction)
+	}
+	return out.String()
+}
+
+// FormatNumber formats an integer or float with the language's separators e.g 12345.5 -> "12,345.5"
+// Other values are formatted with fmt.Sprint.
+func (l *Language) FormatNumber(n interface{}) string {
+	switch v := n.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return l.localizeDigits(fmt.Sprint(v))
+	case float32:
+		return l.localizeDigits(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		return l.localizeDigits(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return fmt.Sprint(n)
+}
+
+// FormatFloat formats f with the given amount of decimals and the language's separators.
+func (l *Language) FormatFloat(f float64, decimals int) string {
+	return l.localizeDigits(strconv.FormatFloat(f, 'f', decimals, 64))
+}
+
+// FormatBytes formats a size in bytes with SI units e.g 1500000 -> "1.5 MB"
+func (l *Language) FormatBytes(b uint64) string {
+	if b < 1000 {
+		return l.FormatNumber(b) + " B"
+	}
+	units := []string{"kB", "MB", "GB", "TB", "PB", "EB"}
+	v := float64(b)
+	i := -1
+	for v >= 1000 && i < len(units)-1 {
+		v /= 1000
+		i++
+	}
+	decimals := 0
+	if v < 10 {
+		decimals = 1
+	}
+	return l.FormatFloat(v, decimals) + " " + units[i]
+}
+
+// localizeChain formats the key with the first language of the chain that has it, falling back to the builtin English.
+// Numbers are formatted with the first language of the chain.
+func localizeChain(chain []*Language, key string, args ...interface{}) string {
+	for _, lang := range chain {
+		if v, ok := lang.Keys[key]; ok {
+			if res, err := FormatMessage(chain[0], v, args...); err == nil {
+				return res
+			}
+		}
+	}
+	return English.Get(key, args...)
+}
+
+var durationUnits = []struct {
+	key  string
+	size time.Duration
+}{
+	{"DURATION_DAYS", 24 * time.Hour},
+	{"DURATION_HOURS", time.Hour},
+	{"DURATION_MINUTES", time.Minute},
+	{"DURATION_SECONDS", time.Second},
+}
+
+// formatDuration formats d with up to maxUnits consecutive units starting from the largest one.
+func formatDuration(chain []*Language, d time.Duration, maxUnits int) string {
+	if d < 0 {
+		d = -d
+	}
+	if d < time.Second {
+		ms := math.Round(float64(d)/float64(time.Millisecond)*100) / 100
+		return localizeChain(chain, "DURATION_MILLISECONDS", LocaleArgs{"n": ms})
+	}
+
+	start := len(durationUnits) - 1
+	for i, unit := range durationUnits {
+		if d >= unit.size {
+			start = i
+			break
+		}
+	}
+	end := start + maxUnits
+	if end > len(durationUnits) {
+		end = len(durationUnits)
+	}
+
+	parts := make([]string, 0, maxUnits)
+	for _, unit := range durationUnits[start:end] {
+		if n := int64(d / unit.size); n != 0 {
+			parts = append(parts, localizeChain(chain, unit.key, LocaleArgs{"n": n}))
+		}
+		d %= unit.size
+	}
+	return strings.Join(parts, localizeChain(chain, "DURATION_SEPARATOR"))
+}
+
+// FormatDuration formats d with its two largest units e.g "2 hours, 5 minutes"
+// Durations under a second are formatted in milliseconds.
+// The units are localized with the DURATION_* keys of the language or English,
+// use ctx.FormatDuration to also look them up in the parents and the default locale.
+func (l *Language) FormatDuration(d time.Duration) string {
+	return formatDuration([]*Language{l}, d, 2)
+}
+
+// FormatRelative formats t relative to now e.g "3 hours ago" or "in 5 minutes"
+// Like FormatDuration only the language and English are used, see ctx.FormatRelative.
+func (l *Language) FormatRelative(t time.Time) string {
+	return formatRelative([]*Language{l}, t, time.Now())
+}
+
+func formatRelative(chain []*Language, t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d > -time.Second && d < time.Second:
+		return localizeChain(chain, "RELATIVE_NOW")
+	case d > 0:
+		return localizeChain(chain, "RELATIVE_PAST", LocaleArgs{"duration": formatDuration(chain, d, 1)})
+	default:
+		return localizeChain(chain, "RELATIVE_FUTURE", LocaleArgs{"duration": formatDuration(chain, d, 1)})
+	}
+}
@@ -0,0 +1,85 @@
+package gocto
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatNumber(t *testing.T) {
+	en := NewLanguage("en-US")
+	de := NewLanguage("de-DE")
+	cases := []struct {
+		lang   *Language
+		n      interface{}
+		expect string
+	}{
+		{en, 0, "0"},
+		{en, 999, "999"},
+		{en, 1000, "1,000"},
+		{en, -1234567, "-1,234,567"},
+		{en, 12345.5, "12,345.5"},
+		{de, uint64(1234567), "1.234.567"},
+		{de, 0.25, "0,25"},
+		{en, "text", "text"},
+	}
+	for _, c := range cases {
+		if res := c.lang.FormatNumber(c.n); res != c.expect {
+			t.Errorf("Expected %s FormatNumber(%v) to return %q but got %q", c.lang.Name, c.n, c.expect, res)
+		}
+	}
+
+	if res := de.FormatBytes(1500000); res != "1,5 MB" {
+		t.Errorf("Expected FormatBytes(1500000) to return \"1,5 MB\" but got %q", res)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	en := NewLanguage("en-US")
+	cases := map[time.Duration]string{
+		1500 * time.Microsecond:       "1.5ms",
+		time.Second:                   "1 second",
+		90 * time.Second:              "1 minute, 30 seconds",
+		2*time.Hour + 5*time.Second:   "2 hours",
+		49*time.Hour + 30*time.Minute: "2 days, 1 hour",
+		-3 * time.Minute:              "3 minutes",
+	}
+	for d, expect := range cases {
+		if res := en.FormatDuration(d); res != expect {
+			t.Errorf("Expected FormatDuration(%v) to return %q but got %q", d, expect, res)
+		}
+	}
+
+	now := time.Now()
+	if res := formatRelative([]*Language{en}, now.Add(-3*time.Hour-time.Minute), now); res != "3 hours ago" {
+		t.Errorf("Expected \"3 hours ago\" but got %q", res)
+	}
+	if res := formatRelative([]*Language{en}, now.Add(5*time.Minute), now); res != "in 5 minutes" {
+		t.Errorf("Expected \"in 5 minutes\" but got %q", res)
+	}
+	if res := formatRelative([]*Language{en}, now, now); res != "just now" {
+		t.Errorf("Expected \"just now\" but got %q", res)
+	}
+}
+
+func TestFormatDurationParent(t *testing.T) {
+	bot := &Bot{Languages: make(map[string]*Language)}
+	pt := NewLanguage("pt").Set("DURATION_SECONDS", "{n, plural, one {# segundo} other {# segundos}}")
+	ptBR := NewLanguage("pt-BR")
+	fr := NewLanguage("fr").Set("DURATION_MINUTES", "{n, plural, one {# minute} other {# minutes (fr)}}")
+	bot.AddLanguage(ptBR).AddLanguage(pt).AddLanguage(fr)
+	bot.DefaultLocale = fr
+	ctx := &CommandContext{Bot: bot, Locale: ptBR}
+
+	if res := ctx.FormatDuration(5 * time.Second); res != "5 segundos" {
+		t.Errorf("Expected the parent's units to be used, got %q", res)
+	}
+	if res := ctx.FormatDuration(2 * time.Minute); res != "2 minutes (fr)" {
+		t.Errorf("Expected the default locale's units to be used, got %q", res)
+	}
+	if res := ctx.FormatDuration(3 * time.Hour); res != "3 hours" {
+		t.Errorf("Expected units missing from the chain to fallback to English, got %q", res)
+	}
+	if res := ptBR.FormatDuration(5 * time.Second); res != "5 seconds" {
+		t.Errorf("Expected the language alone to fallback to English, got %q", res)
+	}
+}
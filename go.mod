@@ -5,5 +5,4 @@ go 1.13
 require (
 	github.com/jonas747/discordgo v1.4.0
 	github.com/andersfylling/disgord v0.16.5
-	github.com/dustin/go-humanize v1.0.0
 )
@@ -2,12 +2,14 @@ package gocto
 
 import (
 	"fmt"
-	"github.com/dustin/go-humanize"
 	"github.com/jonas747/discordgo"
+	"math"
 	"os"
 	"os/signal"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -28,9 +30,18 @@ type Bot struct {
 	Commands         map[string]*Command // Map of commands.
 	CommandsRan      int                 // Commands ran.
 	Monitors         map[string]*Monitor // Map of monitors.
+	monitorOrder     []*Monitor          // Monitors sorted by priority.
+	monitorLock      sync.RWMutex
+	EventMonitors    map[string]*EventMonitor
+	eventOrder       []*EventMonitor
 	aliases          map[string]string
+	localeAliases    map[string]map[string]string // locale -> alias -> command name
 	CommandCooldowns map[int64]map[string]time.Time
-	CommandEdits     map[int64]int64
+	Responses        ResponseStore        // Stores the responses of commands to edit and delete them. (default: in memory)
+	DeleteResponses  bool                 // Wether to delete the responses of a command when its message is deleted. (default: false)
+	ResponseLifetime time.Duration        // How long the responses of a command are tracked, older ones are pruned hourly. (default: 1 hour)
+	Deletions        *DeleteScheduler     // Deletes responses after their TTL.
+	CommandEdits     map[int64]int64      // Deprecated: Unused and always empty, the responses of commands are tracked in Responses.
 	OwnerID          int64                // Bot owner's ID (default: fetched from application info)
 	InvitePerms      int                  // Permissions bits to use for the invite link. (default: 3072)
 	Languages        map[string]*Language // Map of languages.
@@ -40,9 +51,14 @@ type Bot struct {
 	ListHandler      ListHandler
 	MentionPrefix    bool // Wether to allow @mention of the bot to be used as a prefix too. (default: true)
 	sweepTicker      *time.Ticker
-	Application      *discordgo.Application // The bot's application.
-	Uptime           time.Time              // The time the bot hit ready event.
-	Color            int                    // The color used in builtin commands's embeds.
+	Application      *discordgo.Application    // The bot's application.
+	Uptime           time.Time                 // The time the bot hit ready event.
+	Color            int                       // The color used in builtin commands's embeds.
+	Dispatcher       *Dispatcher               // Runs asynchronous monitors, nil to start a goroutine for each. (default: nil)
+	Reactions        *ReactionRouter           // Routes reactions to paginators, menus and collectors by message ID.
+	Answers          *AnswerRouter             // Routes the messages paginators and prompts wait for to them, before the commands see them.
+	Templates        map[string]*EmbedTemplate // Named embed templates, the builtins use "help" and "stats" when present.
+	LongReplies      LongReply                 // What ctx.Reply does with content over MessageLimit. (default: LongReplyAsIs)
 }
 
 // New creates a new sapphire bot, pass in a discordgo instance configured with your token.
@@ -63,12 +79,22 @@ func New(s *discordgo.Session) *Bot {
 		},
 		Commands:         make(map[string]*Command),
 		aliases:          make(map[string]string),
+		localeAliases:    make(map[string]map[string]string),
 		Languages:        make(map[string]*Language),
 		CommandsRan:      0,
 		InvitePerms:      3072,
 		CommandCooldowns: make(map[int64]map[string]time.Time),
+		Responses:        NewMemoryResponseStore(),
+		DeleteResponses:  false,
+		ResponseLifetime: time.Hour,
+		Deletions:        NewDeleteScheduler(s),
 		CommandEdits:     make(map[int64]int64),
 		Monitors:         make(map[string]*Monitor),
+		EventMonitors:    make(map[string]*EventMonitor),
+		Reactions:        NewReactionRouter(),
+		Answers:          NewAnswerRouter(),
+		Templates:        make(map[string]*EmbedTemplate),
+		LongReplies:      LongReplyAsIs,
 		CommandTyping:    true,
 		sweepTicker:      time.NewTicker(1 * time.Hour),
 		Application:      nil,
@@ -78,15 +104,21 @@ func New(s *discordgo.Session) *Bot {
 	bot.AddLanguage(English)
 	bot.SetDefaultLocale("en-US")
 	bot.AddMonitor(NewMonitor("commandHandler", CommandHandlerMonitor).AllowEdits())
+	bot.AddMonitor(newAnswersMonitor())
 	s.AddHandler(monitorListener(bot))
 	s.AddHandler(monitorEditListener(bot))
+	addEventListeners(bot, s)
+	addResponseListeners(bot, s)
+	addDeletionListeners(bot, s)
+	bot.Reactions.AddHandlers(s)
 	s.AddHandlerOnce(func(s *discordgo.Session, ready *discordgo.Ready) {
 		bot.Uptime = time.Now()
 
 		go func() {
-			<-bot.sweepTicker.C
-			bot.CommandCooldowns = make(map[int64]map[string]time.Time)
-			bot.CommandEdits = make(map[int64]int64)
+			for range bot.sweepTicker.C {
+				bot.CommandCooldowns = make(map[int64]map[string]time.Time)
+				bot.pruneResponses()
+			}
 		}()
 
 		// TODO: for some reason it says bots cannot use this endpoint, i've seen a similar usecase before
@@ -112,6 +144,12 @@ func (bot *Bot) SetInvitePerms(bits int) *Bot {
 	return bot
 }
 
+// SetLongReplies sets what ctx.Reply does with content over MessageLimit.
+func (bot *Bot) SetLongReplies(mode LongReply) *Bot {
+	bot.LongReplies = mode
+	return bot
+}
+
 func (bot *Bot) SetErrorHandler(fn ErrorHandler) *Bot {
 	bot.ErrorHandler = fn
 	return bot
@@ -155,6 +193,32 @@ func (bot *Bot) Wait() {
 	// Cleanly close down the Discord session.
 	bot.Session.Close()
 	bot.sweepTicker.Stop()
+	bot.Deletions.Stop()
+	if bot.Dispatcher != nil {
+		bot.Dispatcher.Stop()
+	}
+}
+
+// SetDispatcher makes asynchronous monitors (including the command handler) run on the dispatcher's
+// bounded pool of workers instead of a goroutine each, panics in them are reported to the ErrorHandler.
+func (bot *Bot) SetDispatcher(d *Dispatcher) *Bot {
+	d.PanicHandler = func(err interface{}) {
+		bot.ErrorHandler(bot, err)
+	}
+	bot.Dispatcher = d
+	return bot
+}
+
+// dispatch runs the task on the Dispatcher if there is one or in a new goroutine,
+// ErrTaskDropped is passed to the ErrorHandler if the dispatcher drops it.
+func (bot *Bot) dispatch(guildID, channelID int64, task func()) {
+	if bot.Dispatcher == nil {
+		go task()
+		return
+	}
+	if !bot.Dispatcher.Submit(guildID, channelID, task) {
+		bot.ErrorHandler(bot, ErrTaskDropped)
+	}
 }
 
 func (bot *Bot) AddCommand(cmd *Command) *Bot {
@@ -163,11 +227,24 @@ func (bot *Bot) AddCommand(cmd *Command) *Bot {
 		for _, a := range c.Aliases {
 			delete(bot.aliases, a)
 		}
+		for locale, aliases := range c.LocaleAliases {
+			for _, a := range aliases {
+				delete(bot.localeAliases[locale], a)
+			}
+		}
 	}
 	bot.Commands[cmd.Name] = cmd
 	for _, alias := range cmd.Aliases {
 		bot.aliases[alias] = cmd.Name
 	}
+	for locale, aliases := range cmd.LocaleAliases {
+		if _, ok := bot.localeAliases[locale]; !ok {
+			bot.localeAliases[locale] = make(map[string]string)
+		}
+		for _, alias := range aliases {
+			bot.localeAliases[locale][alias] = cmd.Name
+		}
+	}
 	return bot
 }
 
@@ -183,6 +260,19 @@ func (bot *Bot) GetCommand(name string) *Command {
 	return nil
 }
 
+// GetLocalizedCommand is like GetCommand but also resolves the locale aliases of the languages in locales.
+func (bot *Bot) GetLocalizedCommand(name string, locales []*Language) *Command {
+	if cmd := bot.GetCommand(name); cmd != nil {
+		return cmd
+	}
+	for _, lang := range locales {
+		if alias, ok := bot.localeAliases[lang.Name][name]; ok {
+			return bot.Commands[alias]
+		}
+	}
+	return nil
+}
+
 func (bot *Bot) Connect() error {
 	return bot.Session.Open()
 }
@@ -200,11 +290,66 @@ func (bot *Bot) AddLanguage(lang *Language) *Bot {
 	return bot
 }
 
+// LocaleChain returns the languages to lookup keys from for the locale name, in order.
+// It walks each language's parent (e.g pt-BR -> pt) and always ends with the DefaultLocale,
+// unknown names are skipped so "fr-CA" still resolves to "fr" if only that one is added.
+func (bot *Bot) LocaleChain(name string) []*Language {
+	chain := make([]*Language, 0, 3)
+	seen := make(map[string]bool)
+	for name != "" && !seen[name] {
+		seen[name] = true
+		lang, ok := bot.Languages[name]
+		if !ok {
+			name = parentTag(name)
+			continue
+		}
+		chain = append(chain, lang)
+		name = lang.ParentName()
+	}
+	if bot.DefaultLocale != nil && !seen[bot.DefaultLocale.Name] {
+		chain = append(chain, bot.DefaultLocale)
+	}
+	return chain
+}
+
+// FindLanguage returns the closest added language for the locale name, see LocaleChain.
+// Useful in a LocaleHandler to map e.g a user's "fr-CA" setting to an added "fr".
+func (bot *Bot) FindLanguage(name string) *Language {
+	chain := bot.LocaleChain(name)
+	if len(chain) == 0 {
+		return nil
+	}
+	return chain[0]
+}
+
 func (bot *Bot) AddMonitor(m *Monitor) *Bot {
+	bot.monitorLock.Lock()
+	defer bot.monitorLock.Unlock()
+	if old, ok := bot.Monitors[m.Name]; ok {
+		for i, monitor := range bot.monitorOrder {
+			if monitor == old {
+				bot.monitorOrder = append(bot.monitorOrder[:i], bot.monitorOrder[i+1:]...)
+				break
+			}
+		}
+	}
 	bot.Monitors[m.Name] = m
+	bot.monitorOrder = append(bot.monitorOrder, m)
 	return bot
 }
 
+// sortedMonitors returns the monitors sorted by priority, equal priorities keep the order they were added in.
+// Priorities are read on every call so they can be changed at runtime.
+func (bot *Bot) sortedMonitors() []*Monitor {
+	bot.monitorLock.RLock()
+	monitors := append([]*Monitor{}, bot.monitorOrder...)
+	bot.monitorLock.RUnlock()
+	sort.SliceStable(monitors, func(i, j int) bool {
+		return monitors[i].Priority > monitors[j].Priority
+	})
+	return monitors
+}
+
 func (bot *Bot) CheckCooldown(userID int64, command string, cooldownSec int) (bool, int) {
 	if cooldownSec == 0 {
 		return true, 0
@@ -226,7 +371,8 @@ func (bot *Bot) CheckCooldown(userID int64, command string, cooldownSec int) (bo
 	}
 
 	if !time.Now().After(last.Add(cooldown)) {
-		return false, int(time.Until(last.Add(cooldown)).Seconds())
+		// Rounded up so the wait is never reported as 0 seconds.
+		return false, int(math.Ceil(time.Until(last.Add(cooldown)).Seconds()))
 	}
 
 	user[command] = time.Now()
@@ -242,49 +388,50 @@ func (bot *Bot) LoadBuiltins() *Bot {
 		}
 		taken := time.Duration(time.Now().UnixNano() - bottime.UnixNano())
 		started := time.Now()
-		ctx.EditLocale(msg, "COMMAND_PING_PONG", taken.String(), -1)
+		ctx.EditLocale(msg, "COMMAND_PING_PONG", LocaleArgs{"http": ctx.FormatDuration(taken), "gateway": "n/a"})
 		httpPing := time.Since(started)
 
-		ctx.EditLocale(msg, "COMMAND_PING_PONG", taken.String(), httpPing.String())
+		ctx.EditLocale(msg, "COMMAND_PING_PONG", LocaleArgs{"http": ctx.FormatDuration(taken), "gateway": ctx.FormatDuration(httpPing)})
 	}).SetDescription("Pong! Responds with Bot latency."))
 
 	bot.AddCommand(NewCommand("help", "General", func(ctx *CommandContext) {
 		if ctx.HasArgs() {
-			cmd := bot.GetCommand(ctx.Args[0].AsString())
+			cmd := bot.GetLocalizedCommand(ctx.Args[0].AsString(), ctx.Locales)
 			if cmd == nil {
-				ctx.Reply("Unknown Command.")
+				ctx.ReplyLocale("COMMAND_HELP_UNKNOWN")
 				return
 			}
-			var aliases string = "None"
+			var aliases string = ctx.Localize("COMMAND_HELP_NONE")
 
-			if len(cmd.Aliases) > 0 {
-				aliases = strings.Join(cmd.Aliases, ", ")
+			if localized := cmd.LocalizedAliases(ctx); len(localized) > 0 {
+				aliases = strings.Join(localized, ", ")
 			}
 
 			extra := ""
 			if cmd.AvailableTags != "" {
-				extra = "Flags: " + cmd.AvailableTags
+				extra = ctx.Localize("COMMAND_HELP_FLAGS", LocaleArgs{"flags": cmd.AvailableTags})
 			}
 			ctx.BuildEmbed(NewEmbed().
-				SetDescription(fmt.Sprintf("**Name:** %s\n**Description:** %s\n**Category:** %s\n**Aliases:** %s\n**Usage:** %s \n%s",
-					cmd.Name,
-					cmd.Description,
-					cmd.Category,
-					aliases,
-					fmt.Sprintf("%s%s %s", ctx.Prefix, cmd.Name, HumanizeUsage(cmd.UsageString)),
-					extra,
-				)).SetColor(bot.Color).SetTitle("Command Help"))
+				SetDescription(ctx.Localize("COMMAND_HELP_DETAILS", LocaleArgs{
+					"name":        cmd.LocalizedName(ctx),
+					"description": cmd.LocalizedDescription(ctx),
+					"category":    cmd.LocalizedCategory(ctx),
+					"aliases":     aliases,
+					"usage":       fmt.Sprintf("%s%s %s", ctx.Prefix, cmd.LocalizedName(ctx), cmd.LocalizedUsage(ctx)),
+					"extra":       extra,
+				})).SetColor(bot.Color).SetTitle(ctx.Localize("COMMAND_HELP_TITLE")))
 			return
 		}
 
 		categories := make(map[string][]string)
 		for _, v := range bot.Commands {
-			_, ok := categories[v.Category]
+			category := v.LocalizedCategory(ctx)
+			_, ok := categories[category]
 			if !ok {
-				categories[v.Category] = []string{}
+				categories[category] = []string{}
 			}
 			if !v.OwnerOnly || ctx.Author.ID == ctx.Bot.OwnerID {
-				categories[v.Category] = append(categories[v.Category], v.Name)
+				categories[category] = append(categories[category], v.LocalizedName(ctx))
 			}
 		}
 
@@ -294,21 +441,46 @@ func (bot *Bot) LoadBuiltins() *Bot {
 			}
 		}
 
-		var embed = &discordgo.MessageEmbed{
-			Title:  "Commands",
-			Color:  bot.Color,
-			Footer: &discordgo.MessageEmbedFooter{Text: "For more info on a command use: " + ctx.Prefix + "help <command>"},
-			Author: &discordgo.MessageEmbedAuthor{IconURL: ctx.Author.AvatarURL("256"), Name: ctx.Author.Username},
+		if ctx.HasFlag("menu") && len(categories) <= len(NumberEmojis) {
+			names := make([]string, 0, len(categories))
+			for cat := range categories {
+				names = append(names, cat)
+			}
+			sort.Strings(names)
+
+			menu := NewMenuForContext(ctx).SetTitle(ctx.Localize("COMMAND_HELP_MENU_TITLE")).SetDelete(true)
+			for _, cat := range names {
+				menu.AddOption(cat, cat)
+			}
+			selected, err := menu.Run()
+			if err != nil || len(selected) == 0 {
+				return
+			}
+			cat := selected[0].Label
+			ctx.BuildEmbed(NewEmbed().
+				SetTitle(cat).
+				SetDescription(strings.Join(categories[cat], ", ")).
+				SetFooter(ctx.Localize("COMMAND_HELP_FOOTER", LocaleArgs{"prefix": ctx.Prefix})).
+				SetColor(bot.Color))
+			return
 		}
 
+		embed := ctx.TemplateFunc("help", LocaleArgs{"prefix": ctx.Prefix, "user": ctx.Author.Username, "avatar": ctx.Author.AvatarURL("256")}, func() *Embed {
+			return NewEmbed().
+				SetTitle(ctx.Localize("COMMAND_HELP_LIST_TITLE")).
+				SetColor(bot.Color).
+				SetFooter(ctx.Localize("COMMAND_HELP_FOOTER", LocaleArgs{"prefix": ctx.Prefix})).
+				SetAuthor(ctx.Author.Username, ctx.Author.AvatarURL("256"))
+		})()
+
 		for cat, cmds := range categories {
 			var field = &discordgo.MessageEmbedField{Name: cat, Value: ""}
 			field.Value = strings.Join(cmds, ", ")
 			field.Inline = true
 			embed.Fields = append(embed.Fields, field)
 		}
-		ctx.ReplyEmbed(embed)
-	}).SetDescription("Shows a list of all commands.").SetUsage("[command:string]").AddAliases("h", "cmds", "commands"))
+		ctx.BuildEmbed(embed)
+	}).SetDescription("Shows a list of all commands.").SetUsage("[command:string]").AddAliases("h", "cmds", "commands").SetAvailableTags("--menu"))
 
 	bot.AddCommand(NewCommand("stats", "General", func(ctx *CommandContext) {
 		stats := &runtime.MemStats{}
@@ -321,29 +493,52 @@ func (bot *Bot) LoadBuiltins() *Bot {
 			channels += len(guild.Channels)
 		}
 
-		ctx.BuildEmbed(NewEmbed().
-			SetTitle("Stats").
-			SetAuthor(ctx.Session.State.User.Username, ctx.Session.State.User.AvatarURL("256")).
-			SetColor(bot.Color).
-			AddField("**Go Version**", strings.TrimPrefix(runtime.Version(), "go")).
-			AddField("**DiscordGo Version**", discordgo.VERSION).
-			AddField("**Command Stats**", fmt.Sprintf("Total Commands: %d\nCommands Ran: %d", len(bot.Commands), bot.CommandsRan)).
-			AddField("**Bot Stats**", fmt.Sprintf("Guilds: %d\nUsers: %d\nChannels: %d\nUptime: %s", guilds, users, channels, humanize.RelTime(bot.Uptime, time.Now(), "", ""))).
-			AddField("**Memory Stats**", fmt.Sprintf("Used: %s / %s\nGarbage Collected: %s\nGC Cycles: %d\nForced GC Cycles: %d\nLast GC: %s\nNext GC Target: %s\nGoroutines: %d",
-				humanize.Bytes(stats.Alloc),
-				humanize.Bytes(stats.Sys),
-				humanize.Bytes(stats.TotalAlloc-stats.Alloc),
-				stats.NumGC,
-				stats.NumForcedGC,
-				humanize.Time(time.Unix(0, int64(stats.LastGC))),
-				humanize.Bytes(stats.NextGC),
-				runtime.NumGoroutine(),
-			)).
-			AddField("**Technical Info**", fmt.Sprintf("CPU Cores: %d\nOS/Arch: %s/%s",
-				runtime.NumCPU(),
-				runtime.GOOS,
-				runtime.GOARCH,
-			)).InlineAllFields())
+		embed := ctx.TemplateFunc("stats", LocaleArgs{"user": ctx.Session.State.User.Username, "avatar": ctx.Session.State.User.AvatarURL("256")}, func() *Embed {
+			return NewEmbed().
+				SetTitle(ctx.Localize("COMMAND_STATS_TITLE")).
+				SetAuthor(ctx.Session.State.User.Username, ctx.Session.State.User.AvatarURL("256")).
+				SetColor(bot.Color)
+		})().
+			AddField(ctx.Localize("COMMAND_STATS_GO_VERSION"), strings.TrimPrefix(runtime.Version(), "go")).
+			AddField(ctx.Localize("COMMAND_STATS_DISCORDGO_VERSION"), discordgo.VERSION).
+			AddField(ctx.Localize("COMMAND_STATS_COMMANDS_TITLE"), ctx.Localize("COMMAND_STATS_COMMANDS", LocaleArgs{
+				"total": ctx.Locale.FormatNumber(len(bot.Commands)),
+				"ran":   ctx.Locale.FormatNumber(bot.CommandsRan),
+			})).
+			AddField(ctx.Localize("COMMAND_STATS_BOT_TITLE"), ctx.Localize("COMMAND_STATS_BOT", LocaleArgs{
+				"guilds":   ctx.Locale.FormatNumber(guilds),
+				"users":    ctx.Locale.FormatNumber(users),
+				"channels": ctx.Locale.FormatNumber(channels),
+				"uptime":   ctx.FormatDuration(time.Since(bot.Uptime)),
+			})).
+			AddField(ctx.Localize("COMMAND_STATS_MEMORY_TITLE"), ctx.Localize("COMMAND_STATS_MEMORY", LocaleArgs{
+				"used":       ctx.Locale.FormatBytes(stats.Alloc),
+				"sys":        ctx.Locale.FormatBytes(stats.Sys),
+				"collected":  ctx.Locale.FormatBytes(stats.TotalAlloc - stats.Alloc),
+				"cycles":     ctx.Locale.FormatNumber(stats.NumGC),
+				"forced":     ctx.Locale.FormatNumber(stats.NumForcedGC),
+				"lastGC":     ctx.FormatRelative(time.Unix(0, int64(stats.LastGC))),
+				"nextGC":     ctx.Locale.FormatBytes(stats.NextGC),
+				"goroutines": ctx.Locale.FormatNumber(runtime.NumGoroutine()),
+			})).
+			AddField(ctx.Localize("COMMAND_STATS_TECHNICAL_TITLE"), ctx.Localize("COMMAND_STATS_TECHNICAL", LocaleArgs{
+				"cores": runtime.NumCPU(),
+				"os":    runtime.GOOS,
+				"arch":  runtime.GOARCH,
+			}))
+
+		if bot.Dispatcher != nil {
+			dstats := bot.Dispatcher.Stats()
+			embed.AddField(ctx.Localize("COMMAND_STATS_DISPATCHER_TITLE"), ctx.Localize("COMMAND_STATS_DISPATCHER", LocaleArgs{
+				"workers":   ctx.Locale.FormatNumber(dstats.Workers),
+				"queued":    ctx.Locale.FormatNumber(dstats.Queued),
+				"size":      ctx.Locale.FormatNumber(dstats.QueueSize),
+				"running":   ctx.Locale.FormatNumber(dstats.Running),
+				"processed": ctx.Locale.FormatNumber(dstats.Processed),
+				"dropped":   ctx.Locale.FormatNumber(dstats.Dropped),
+			}))
+		}
+		ctx.BuildEmbed(embed.InlineAllFields())
 	}).SetDescription("Stats for nerds.").AddAliases("botstats", "info"))
 
 	bot.AddCommand(NewCommand("invite", "General", func(ctx *CommandContext) {
@@ -385,12 +580,45 @@ func (bot *Bot) LoadBuiltins() *Bot {
 		runtime.ReadMemStats(before)
 
 		bot.CommandCooldowns = make(map[int64]map[string]time.Time)
-		bot.CommandEdits = make(map[int64]int64)
+		bot.clearResponses()
 		runtime.GC()
 		after := &runtime.MemStats{}
 		runtime.ReadMemStats(after)
-		ctx.Reply("Forced Garbage Collection.\n  - Freed **%s**\n  - %d Objects Collected.\n  - Took **%d**μs",
-			humanize.Bytes(before.Alloc-after.Alloc), after.Frees-before.Frees, after.PauseTotalNs-before.PauseTotalNs)
+		ctx.ReplyLocale("COMMAND_GC", LocaleArgs{
+			"freed":   ctx.Locale.FormatBytes(before.Alloc - after.Alloc),
+			"objects": ctx.Locale.FormatNumber(after.Frees - before.Frees),
+			"took":    ctx.FormatDuration(time.Duration(after.PauseTotalNs - before.PauseTotalNs)),
+		})
 	}).SetDescription("Forces a garbage collection cycle.").AddAliases("garbagecollect", "forcegc", "runtime.GC()").SetOwnerOnly(true))
+
+	bot.AddCommand(NewCommand("languages", "Owner", func(ctx *CommandContext) {
+		audits := bot.AuditLanguages()
+		if len(audits) == 0 {
+			ctx.ReplyLocale("COMMAND_LANGUAGES_NONE")
+			return
+		}
+
+		p := NewPaginatorForContext(ctx)
+		p.SetTemplate(func() *Embed { return NewEmbed().SetColor(bot.Color) })
+		embeds := make([]*Embed, 0, len(audits))
+		for _, audit := range audits {
+			em := p.Template().SetTitle(ctx.Localize("COMMAND_LANGUAGES_TITLE", LocaleArgs{"language": audit.Language, "reference": audit.Reference}))
+			if audit.OK() {
+				em.SetDescription(ctx.Localize("COMMAND_LANGUAGES_OK"))
+			}
+			// Long lists are spread over several fields, and pages when they don't fit in one embed.
+			addKeys := func(title string, keys []string) {
+				for _, value := range keyFields(keys) {
+					em.AddField(ctx.Localize(title, LocaleArgs{"count": len(keys)}), value)
+				}
+			}
+			addKeys("COMMAND_LANGUAGES_MISSING", audit.Missing)
+			addKeys("COMMAND_LANGUAGES_EXTRA", audit.Extra)
+			addKeys("COMMAND_LANGUAGES_MISMATCHED", audit.Mismatched)
+			embeds = append(embeds, em)
+		}
+		p.AddSplitPages(embeds...)
+		p.Run()
+	}).SetDescription("Reports missing and mismatched keys of the languages.").AddAliases("audit").SetOwnerOnly(true))
 	return bot
 }
@@ -5,95 +5,62 @@ import (
 	"strings"
 )
 
+// Permission describes a permission bit with a locale key and an English name.
+type Permission struct {
+	Bit  int
+	Key  string
+	Name string
+}
+
+// PermissionList is the list of permissions in the order they are displayed.
+var PermissionList = []Permission{
+	{discordgo.PermissionAdministrator, "PERMISSION_ADMINISTRATOR", "Administrator"},
+	{discordgo.PermissionViewAuditLogs, "PERMISSION_VIEW_AUDIT_LOGS", "View Audit Log"},
+	{discordgo.PermissionManageServer, "PERMISSION_MANAGE_SERVER", "Manage Server"},
+	{discordgo.PermissionManageRoles, "PERMISSION_MANAGE_ROLES", "Manage Roles"},
+	{discordgo.PermissionManageChannels, "PERMISSION_MANAGE_CHANNELS", "Manage Channels"},
+	{discordgo.PermissionKickMembers, "PERMISSION_KICK_MEMBERS", "Kick Members"},
+	{discordgo.PermissionBanMembers, "PERMISSION_BAN_MEMBERS", "Ban Members"},
+	{discordgo.PermissionCreateInstantInvite, "PERMISSION_CREATE_INSTANT_INVITE", "Create Instant Invite"},
+	{discordgo.PermissionChangeNickname, "PERMISSION_CHANGE_NICKNAME", "Change Nickname"},
+	{discordgo.PermissionManageNicknames, "PERMISSION_MANAGE_NICKNAMES", "Manage Nicknames"},
+	{discordgo.PermissionManageEmojis, "PERMISSION_MANAGE_EMOJIS", "Manage Emojis"},
+	{discordgo.PermissionManageWebhooks, "PERMISSION_MANAGE_WEBHOOKS", "Manage Webhooks"},
+	{discordgo.PermissionReadMessages, "PERMISSION_READ_MESSAGES", "View Channels"},
+	{discordgo.PermissionSendMessages, "PERMISSION_SEND_MESSAGES", "Send Messages"},
+	{discordgo.PermissionSendTTSMessages, "PERMISSION_SEND_TTS_MESSAGES", "Send TTS Messages"},
+	{discordgo.PermissionManageMessages, "PERMISSION_MANAGE_MESSAGES", "Manage Messages"},
+	{discordgo.PermissionEmbedLinks, "PERMISSION_EMBED_LINKS", "Embed Links"},
+	{discordgo.PermissionAttachFiles, "PERMISSION_ATTACH_FILES", "Attach Files"},
+	{discordgo.PermissionReadMessageHistory, "PERMISSION_READ_MESSAGE_HISTORY", "Read Message History"},
+	{discordgo.PermissionMentionEveryone, "PERMISSION_MENTION_EVERYONE", "Mention Everyone"},
+	{discordgo.PermissionUseExternalEmojis, "PERMISSION_USE_EXTERNAL_EMOJIS", "Use External Emojis"},
+	{discordgo.PermissionAddReactions, "PERMISSION_ADD_REACTIONS", "Add Reactions"},
+	{discordgo.PermissionVoiceConnect, "PERMISSION_VOICE_CONNECT", "Voice Connect"},
+	{discordgo.PermissionVoiceSpeak, "PERMISSION_VOICE_SPEAK", "Voice Speak"},
+	{discordgo.PermissionVoiceMuteMembers, "PERMISSION_VOICE_MUTE_MEMBERS", "Voice Mute Members"},
+	{discordgo.PermissionVoiceDeafenMembers, "PERMISSION_VOICE_DEAFEN_MEMBERS", "Voice Deafen Members"},
+	{discordgo.PermissionVoiceMoveMembers, "PERMISSION_VOICE_MOVE_MEMBERS", "Voice Move Members"},
+	{discordgo.PermissionVoiceUseVAD, "PERMISSION_VOICE_USE_VAD", "Voice Use Voice Acivity"},
+}
+
+// GetPermissionsText returns the English names of the permissions in the bits.
 func GetPermissionsText(permissions int) string {
+	return GetPermissionsTextFunc(permissions, func(p Permission) string {
+		return p.Name
+	})
+}
+
+// GetPermissionsTextFunc is like GetPermissionsText but names each permission with name, e.g to localize them.
+func GetPermissionsTextFunc(permissions int, name func(p Permission) string) string {
 	if permissions == 0 {
 		return "/"
 	}
-	var result string
-	if permissions&discordgo.PermissionAdministrator == discordgo.PermissionAdministrator {
-		result += "Administrator, "
-	}
-	if permissions&discordgo.PermissionViewAuditLogs == discordgo.PermissionViewAuditLogs {
-		result += "View Audit Log, "
-	}
-	if permissions&discordgo.PermissionManageServer == discordgo.PermissionManageServer {
-		result += "Manage Server, "
-	}
-	if permissions&discordgo.PermissionManageRoles == discordgo.PermissionManageRoles {
-		result += "Manage Roles, "
-	}
-	if permissions&discordgo.PermissionManageChannels == discordgo.PermissionManageChannels {
-		result += "Manage Channels, "
-	}
-	if permissions&discordgo.PermissionKickMembers == discordgo.PermissionKickMembers {
-		result += "Kick Members, "
-	}
-	if permissions&discordgo.PermissionBanMembers == discordgo.PermissionBanMembers {
-		result += "Ban Members, "
-	}
-	if permissions&discordgo.PermissionCreateInstantInvite == discordgo.PermissionCreateInstantInvite {
-		result += "Create Instant Invite, "
-	}
-	if permissions&discordgo.PermissionChangeNickname == discordgo.PermissionChangeNickname {
-		result += "Change Nickname, "
-	}
-	if permissions&discordgo.PermissionManageNicknames == discordgo.PermissionManageNicknames {
-		result += "Manage Nicknames, "
-	}
-	if permissions&discordgo.PermissionManageEmojis == discordgo.PermissionManageEmojis {
-		result += "Manage Emojis, "
-	}
-	if permissions&discordgo.PermissionManageWebhooks == discordgo.PermissionManageWebhooks {
-		result += "Manage Webhooks, "
-	}
-	if permissions&discordgo.PermissionReadMessages == discordgo.PermissionReadMessages {
-		result += "View Channels, "
-	}
-	if permissions&discordgo.PermissionSendMessages == discordgo.PermissionSendMessages {
-		result += "Send Messages, "
-	}
-	if permissions&discordgo.PermissionSendTTSMessages == discordgo.PermissionSendTTSMessages {
-		result += "Send TTS Messages, "
-	}
-	if permissions&discordgo.PermissionManageMessages == discordgo.PermissionManageMessages {
-		result += "Manage Messages, "
-	}
-	if permissions&discordgo.PermissionEmbedLinks == discordgo.PermissionEmbedLinks {
-		result += "Embed Links, "
-	}
-	if permissions&discordgo.PermissionAttachFiles == discordgo.PermissionAttachFiles {
-		result += "Attach Files, "
-	}
-	if permissions&discordgo.PermissionReadMessageHistory == discordgo.PermissionReadMessageHistory {
-		result += "Read Message History, "
-	}
-	if permissions&discordgo.PermissionMentionEveryone == discordgo.PermissionMentionEveryone {
-		result += "Mention Everyone, "
-	}
-	if permissions&discordgo.PermissionUseExternalEmojis == discordgo.PermissionUseExternalEmojis {
-		result += "Use External Emojis, "
-	}
-	if permissions&discordgo.PermissionAddReactions == discordgo.PermissionAddReactions {
-		result += "Add Reactions, "
-	}
-	if permissions&discordgo.PermissionVoiceConnect == discordgo.PermissionVoiceConnect {
-		result += "Voice Connect, "
-	}
-	if permissions&discordgo.PermissionVoiceSpeak == discordgo.PermissionVoiceSpeak {
-		result += "Voice Speak, "
-	}
-	if permissions&discordgo.PermissionVoiceMuteMembers == discordgo.PermissionVoiceMuteMembers {
-		result += "Voice Mute Members, "
-	}
-	if permissions&discordgo.PermissionVoiceDeafenMembers == discordgo.PermissionVoiceDeafenMembers {
-		result += "Voice Deafen Members, "
-	}
-	if permissions&discordgo.PermissionVoiceMoveMembers == discordgo.PermissionVoiceMoveMembers {
-		result += "Voice Move Members, "
-	}
-	if permissions&discordgo.PermissionVoiceUseVAD == discordgo.PermissionVoiceUseVAD {
-		result += "Voice Use Voice Acivity, "
+	names := make([]string, 0)
+	for _, p := range PermissionList {
+		if permissions&p.Bit == p.Bit {
+			names = append(names, name(p))
+		}
 	}
-	result = strings.TrimRight(result, ", ")
-	return result
+	return strings.Join(names, ", ")
 }
@@ -0,0 +1,105 @@
+package helpers
+
+import (
+	"github.com/jonas747/discordgo"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Sanitizer neutralizes mentions, markdown and invites in text that came from users before echoing it.
+type Sanitizer struct {
+	Markdown          bool   // Wether to escape markdown, including code blocks.
+	Mentions          bool   // Wether to neutralize @everyone, @here, user and role mentions.
+	ResolveNames      bool   // Wether to replace neutralized user and role mentions with their names when they are in the state.
+	Invites           bool   // Wether to strip invite links.
+	InviteReplacement string // What invite links are replaced with. (default: "")
+}
+
+// NewSanitizer creates a sanitizer that does everything.
+func NewSanitizer() *Sanitizer {
+	return &Sanitizer{
+		Markdown:          true,
+		Mentions:          true,
+		ResolveNames:      true,
+		Invites:           true,
+		InviteReplacement: "",
+	}
+}
+
+// DefaultSanitizer is used by Sanitize.
+var DefaultSanitizer = NewSanitizer()
+
+var (
+	everyoneRegex = regexp.MustCompile("@(everyone|here)")
+	mentionRegex  = regexp.MustCompile(`<@(!|&)?(\d+)>`)
+	inviteRegex   = regexp.MustCompile(`(?i)(?:https?://)?(?:www\.)?(?:discord\.(?:gg|io|me|li)|discord(?:app)?\.com/invite)/[\w-]+`)
+	markdownChars = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`)
+	quoteRegex    = regexp.MustCompile(`(?m)^(\s*)>`)
+)
+
+// Sanitize sanitizes text with the DefaultSanitizer without resolving names.
+func Sanitize(text string) string {
+	return DefaultSanitizer.Sanitize(text, nil, 0)
+}
+
+// EscapeMarkdown escapes markdown characters so text shows as typed.
+func EscapeMarkdown(text string) string {
+	return quoteRegex.ReplaceAllString(markdownChars.Replace(text), `$1\>`)
+}
+
+// StripInvites replaces the invite links in text with replacement.
+func StripInvites(text, replacement string) string {
+	return inviteRegex.ReplaceAllString(text, replacement)
+}
+
+// Sanitize sanitizes text, user and role mentions are resolved to names from the state and guild when ResolveNames is set,
+// state can be nil to not resolve them.
+func (s *Sanitizer) Sanitize(text string, state *discordgo.State, guildID int64) string {
+	if s.Mentions {
+		text = everyoneRegex.ReplaceAllString(text, "@\u200b$1")
+		text = mentionRegex.ReplaceAllStringFunc(text, func(mention string) string {
+			match := mentionRegex.FindStringSubmatch(mention)
+			id, _ := strconv.ParseInt(match[2], 10, 64)
+			if s.ResolveNames && state != nil {
+				if name := mentionName(state, guildID, id, match[1] == "&"); name != "" {
+					// Names are user input too e.g the @everyone role or a member nicknamed @here.
+					return "@\u200b" + neutralizeMentions(name)
+				}
+			}
+			return "<@\u200b" + match[1] + match[2] + ">"
+		})
+	}
+	if s.Invites {
+		text = StripInvites(text, s.InviteReplacement)
+	}
+	if s.Markdown {
+		text = EscapeMarkdown(text)
+	}
+	return text
+}
+
+// neutralizeMentions neutralizes @everyone, @here and mentions without resolving them.
+func neutralizeMentions(text string) string {
+	text = everyoneRegex.ReplaceAllString(text, "@\u200b$1")
+	return mentionRegex.ReplaceAllString(text, "<@\u200b$1$2>")
+}
+
+// mentionName returns the name of the mentioned user or role, empty if it isn't in the state.
+func mentionName(state *discordgo.State, guildID, id int64, role bool) string {
+	if role {
+		if r, err := state.Role(guildID, id); err == nil {
+			return r.Name
+		}
+		return ""
+	}
+	if guildID != 0 {
+		if m, err := state.Member(guildID, id); err == nil && m.User != nil {
+			if m.Nick != "" {
+				return m.Nick
+			}
+			return m.User.Username
+		}
+	}
+	return ""
+}
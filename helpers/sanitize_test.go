@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"github.com/jonas747/discordgo"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"hello @everyone and @here", "hello @\u200beveryone and @\u200bhere"},
+		{"<@123> <@!456> <@&789>", "<@\u200b123> <@\u200b!456> <@\u200b&789>"},
+		{"**bold** _it_ `code` ~~s~~ ||spoiler||", `\*\*bold\*\* \_it\_ \` + "`code\\`" + ` \~\~s\~\~ \|\|spoiler\|\|`},
+		{"> quote\nnot > quote", "\\> quote\nnot > quote"},
+		{"join discord.gg/abc or https://discord.com/invite/xyz", "join  or "},
+	}
+	for _, c := range cases {
+		if res := Sanitize(c.input); res != c.expected {
+			t.Errorf("Sanitize(%q): expected %q but got %q", c.input, c.expected, res)
+		}
+	}
+}
+
+func TestSanitizeResolveNames(t *testing.T) {
+	state := discordgo.NewState()
+	guild := &discordgo.Guild{ID: 1, Roles: []*discordgo.Role{{ID: 2, Name: "Mods"}}}
+	state.GuildAdd(guild)
+	state.MemberAdd(&discordgo.Member{GuildID: 1, Nick: "Gopher", User: &discordgo.User{ID: 3, Username: "gopher"}})
+
+	s := &Sanitizer{Mentions: true, ResolveNames: true}
+	if res := s.Sanitize("<@!3> <@&2> <@4>", state, 1); res != "@\u200bGopher @\u200bMods <@\u200b4>" {
+		t.Errorf("Unexpected result %q", res)
+	}
+}
+
+func TestSanitizeResolvedEveryone(t *testing.T) {
+	state := discordgo.NewState()
+	// The @everyone role has the guild's ID.
+	state.GuildAdd(&discordgo.Guild{ID: 1, Roles: []*discordgo.Role{{ID: 1, Name: "@everyone"}}})
+	state.MemberAdd(&discordgo.Member{GuildID: 1, Nick: "@here", User: &discordgo.User{ID: 3, Username: "gopher"}})
+	state.MemberAdd(&discordgo.Member{GuildID: 1, Nick: "<@&1>", User: &discordgo.User{ID: 4, Username: "sneaky"}})
+
+	s := &Sanitizer{Mentions: true, ResolveNames: true}
+	expected := "@\u200b@\u200beveryone @\u200b@\u200bhere @\u200b<@\u200b&1>"
+	if res := s.Sanitize("<@&1> <@3> <@4>", state, 1); res != expected {
+		t.Errorf("Expected %q but got %q", expected, res)
+	}
+}
@@ -0,0 +1,153 @@
+package helpers
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const codeFence = "```"
+
+// SplitOptions limit the chunks created by SplitText and SplitLines.
+type SplitOptions struct {
+	MaxLength int // Maximum characters in a chunk.
+	MaxLines  int // Maximum lines in a chunk, 0 for no limit.
+}
+
+// SplitText splits text into chunks on line boundaries, see SplitLines.
+func SplitText(text string, opts SplitOptions) []string {
+	if text == "" {
+		return nil
+	}
+	return SplitLines(strings.Split(text, "\n"), opts)
+}
+
+// SplitLines joins lines into chunks of at most opts.MaxLength characters and opts.MaxLines lines.
+// Lines longer than a chunk are split on word boundaries, or wherever needed if they have no spaces.
+// Code blocks cut by a chunk boundary are closed at the end of the chunk and reopened in the next one with the same language.
+func SplitLines(lines []string, opts SplitOptions) []string {
+	var chunks []string
+	var current []string
+	length := 0
+	count := 0
+	fence := "" // The line that opened the current code block, empty if not in one.
+
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		if fence != "" {
+			current = append(current, codeFence)
+		}
+		chunks = append(chunks, strings.Join(current, "\n"))
+		current, length, count = nil, 0, 0
+		if fence != "" {
+			current = []string{fence}
+			length = utf8.RuneCountInString(fence)
+		}
+	}
+
+	add := func(line string) {
+		after := fenceAfter(fence, line)
+		reserve := 0
+		if after != "" {
+			reserve = len(codeFence) + 1
+		}
+		size := utf8.RuneCountInString(line)
+		if len(current) > 0 {
+			size++
+		}
+		if count > 0 && (length+size+reserve > opts.MaxLength || (opts.MaxLines > 0 && count >= opts.MaxLines)) {
+			flush()
+			size = utf8.RuneCountInString(line)
+			if len(current) > 0 {
+				size++
+			}
+		}
+		current = append(current, line)
+		length += size
+		count++
+		fence = after
+		// Reopen with a plain fence when the language leaves no room for a line.
+		if fence != "" && utf8.RuneCountInString(fence)+len(codeFence)+3 > opts.MaxLength {
+			fence = codeFence
+		}
+	}
+
+	// rooms returns the room for a line in an empty chunk, after the reopened code block it's in,
+	// and the room left when the line leaves a code block open and the chunk has to close it.
+	rooms := func() (int, int) {
+		room := opts.MaxLength
+		if fence != "" {
+			room -= utf8.RuneCountInString(fence) + 1
+		}
+		closed := room - len(codeFence) - 1
+		if room < 1 {
+			room = 1
+		}
+		if closed < 1 {
+			closed = 1
+		}
+		return room, closed
+	}
+
+	for _, line := range lines {
+		// Lines too long for a chunk fill their own chunks, cut after the last space that fits if there is one.
+		for {
+			room, closed := rooms()
+			cut := splitIndex(line, room)
+			if fenceAfter(fence, line[:cut]) != "" && utf8.RuneCountInString(line[:cut]) > closed {
+				cut = splitIndex(line, closed)
+			}
+			if cut == len(line) {
+				break
+			}
+			add(line[:cut])
+			flush()
+			line = line[cut:]
+		}
+		add(line)
+	}
+	flush()
+	return chunks
+}
+
+// fenceAfter returns the line that opened the code block the text is in after line, empty if not in one.
+func fenceAfter(fence, line string) string {
+	if strings.Count(line, codeFence)%2 == 0 {
+		return fence
+	}
+	if fence != "" {
+		return ""
+	}
+	trimmed := strings.TrimSpace(line)
+	// A line like ```go opens a block with a language, reopened with it.
+	if strings.HasPrefix(trimmed, codeFence) && !strings.ContainsAny(trimmed[len(codeFence):], " \t`") {
+		return trimmed
+	}
+	return codeFence
+}
+
+// splitIndex returns the byte index to cut line at so it has at most max runes,
+// after the last space that fits if there is one, len(line) if it fits whole.
+func splitIndex(line string, max int) int {
+	cut := runeIndex(line, max)
+	if cut == len(line) {
+		return cut
+	}
+	if space := strings.LastIndexAny(line[:cut], " \t"); space > 0 {
+		cut = space + 1
+	}
+	return cut
+}
+
+// runeIndex returns the byte index of the nth rune.
+func runeIndex(s string, n int) int {
+	i := 0
+	for index := range s {
+		if i == n {
+			return index
+		}
+		i++
+	}
+	return len(s)
+}
@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitLines(t *testing.T) {
+	cases := []struct {
+		lines    []string
+		opts     SplitOptions
+		expected []string
+	}{
+		{[]string{"a", "b", "c"}, SplitOptions{MaxLength: 100}, []string{"a\nb\nc"}},
+		{[]string{"aa", "bb", "cc"}, SplitOptions{MaxLength: 5}, []string{"aa\nbb", "cc"}},
+		{[]string{"a", "b", "c"}, SplitOptions{MaxLength: 100, MaxLines: 2}, []string{"a\nb", "c"}},
+		{[]string{"abcdefghij"}, SplitOptions{MaxLength: 8}, []string{"abcdefgh", "ij"}},
+		{[]string{"ab cd ef gh"}, SplitOptions{MaxLength: 7}, []string{"ab cd ", "ef gh"}},
+		{[]string{"```go", "x := 1", "y := 2", "```", "done"}, SplitOptions{MaxLength: 21},
+			[]string{"```go\nx := 1\n```", "```go\ny := 2\n```\ndone"}},
+	}
+
+	for i, c := range cases {
+		res := SplitLines(c.lines, c.opts)
+		if strings.Join(res, "|") != strings.Join(c.expected, "|") {
+			t.Errorf("Case %d: expected %q but got %q", i, c.expected, res)
+		}
+		for _, chunk := range res {
+			if utf8.RuneCountInString(chunk) > c.opts.MaxLength {
+				t.Errorf("Case %d: chunk %q is longer than %d", i, chunk, c.opts.MaxLength)
+			}
+			if strings.Count(chunk, "```")%2 != 0 {
+				t.Errorf("Case %d: chunk %q has unbalanced code blocks", i, chunk)
+			}
+		}
+	}
+}
+
+func TestSplitLinesMaxLength(t *testing.T) {
+	words := []string{"```", "```go", "word", "a", "", "longerword", "x:=1", "```py", "ü"}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		lines := make([]string, r.Intn(8)+1)
+		for j := range lines {
+			parts := make([]string, r.Intn(12))
+			for k := range parts {
+				parts[k] = words[r.Intn(len(words))]
+			}
+			sep := " "
+			if r.Intn(3) == 0 {
+				sep = ""
+			}
+			lines[j] = strings.Join(parts, sep)
+		}
+		opts := SplitOptions{MaxLength: r.Intn(40) + 20}
+		for _, chunk := range SplitLines(lines, opts) {
+			if n := utf8.RuneCountInString(chunk); n > opts.MaxLength {
+				t.Fatalf("Chunk of %d characters over MaxLength %d for %q: %q", n, opts.MaxLength, lines, chunk)
+			}
+		}
+	}
+}
@@ -1,16 +1,28 @@
 package gocto
 
 import (
-	"fmt"
+	"github.com/Noctember/gocto/helpers"
+	"strings"
 )
 
 type Language struct {
-	Name string
-	Keys map[string]string
+	Name               string
+	Keys               map[string]string
+	Plural             PluralRule // The rule used to pick plural branches. (default: picked from the language name)
+	Parent             string     // The language to fallback to for missing keys. (default: derived from the name e.g pt-BR -> pt)
+	DecimalSeparator   string     // The decimal separator of formatted numbers. (default: picked from the language name)
+	ThousandsSeparator string     // The thousands separator of formatted numbers. (default: picked from the language name)
 }
 
 func NewLanguage(name string) *Language {
-	return &Language{Name: name, Keys: make(map[string]string)}
+	decimal, thousands := separatorsFor(name)
+	return &Language{
+		Name:               name,
+		Keys:               make(map[string]string),
+		Plural:             pluralRuleFor(name),
+		DecimalSeparator:   decimal,
+		ThousandsSeparator: thousands,
+	}
 }
 
 func (l *Language) Merge(other *Language) *Language {
@@ -25,14 +37,65 @@ func (l *Language) Set(key string, value string) *Language {
 	return l
 }
 
-func (l *Language) Get(key string, args ...interface{}) string {
-	v, ok := l.Keys[key]
-	if ok {
-		return fmt.Sprintf(v, args...)
+// SetParent sets the language missing keys fallback to before the default locale.
+func (l *Language) SetParent(parent string) *Language {
+	l.Parent = parent
+	return l
+}
+
+// ParentName returns the name of the language to fallback to, either the explicit Parent
+// or the name with its last BCP-47 subtag removed. Returns an empty string for base languages.
+func (l *Language) ParentName() string {
+	if l.Parent != "" {
+		return l.Parent
+	}
+	return parentTag(l.Name)
+}
+
+// parentTag strips the last subtag of a BCP-47 tag e.g zh-Hant-TW -> zh-Hant -> zh -> ""
+func parentTag(tag string) string {
+	tag = strings.Replace(tag, "_", "-", -1)
+	if i := strings.LastIndexByte(tag, '-'); i != -1 {
+		return tag[:i]
 	}
 	return ""
 }
 
+// SetPluralRule sets the rule used to pick plural branches in messages.
+func (l *Language) SetPluralRule(rule PluralRule) *Language {
+	l.Plural = rule
+	return l
+}
+
+// PluralCategory returns the plural category of n in this language.
+func (l *Language) PluralCategory(n float64) string {
+	if l.Plural == nil {
+		return PluralRuleOneOther(n)
+	}
+	return l.Plural(n)
+}
+
+// Format formats the key with args, see FormatMessage for the message syntax.
+// The error is a *LocaleError, wrapping ErrLocaleNoKey if the key doesn't exist.
+func (l *Language) Format(key string, args ...interface{}) (string, error) {
+	v, ok := l.Keys[key]
+	if !ok {
+		return "", &LocaleError{Locale: l.Name, Key: key, Err: ErrLocaleNoKey}
+	}
+	res, err := FormatMessage(l, v, args...)
+	if err != nil {
+		return res, &LocaleError{Locale: l.Name, Key: key, Err: err}
+	}
+	return res, nil
+}
+
+// Get formats the key with args, returns an empty string if the key doesn't exist.
+// Formatting errors are ignored, use Format to catch them.
+func (l *Language) Get(key string, args ...interface{}) string {
+	res, _ := l.Format(key, args...)
+	return res
+}
+
 func (l *Language) GetDefault(key string, def string, args ...interface{}) string {
 	v := l.Get(key, args...)
 	if v == "" {
@@ -45,7 +108,7 @@ var English = NewLanguage("en-US").
 	Set("LOCALE_NO_KEY", "No localization found for the key \"%s\" Please report this to the developers.").
 	Set("COMMAND_ERROR", "Something went wrong, please try again later.").
 	Set("COMMAND_PING", "Pong!").
-	Set("COMMAND_PING_PONG", "Pong!\nHTTP API: **%s**\nGateway: **%s**").
+	Set("COMMAND_PING_PONG", "Pong!\nHTTP API: **{http}**\nGateway: **{gateway}**").
 	Set("COMMAND_ENABLE_ALREADY", "That command is already enabled!").
 	Set("COMMAND_DISABLE_ALREADY", "That command is already disabled!").
 	Set("COMMAND_ENABLE_SUCCESS", "Successfully enabled the command **%s**").
@@ -54,6 +117,76 @@ var English = NewLanguage("en-US").
 	Set("COMMAND_INVITE", "To invite me to your server: <%s>").
 	Set("COMMAND_OWNER_ONLY", "This command is for the bot owner only!").
 	Set("COMMAND_GUILD_ONLY", "This command can only be used in a server!").
-	Set("COMMAND_COOLDOWN", "You can use this command again in %d seconds.").
+	Set("COMMAND_COOLDOWN", "You can use this command again in {duration}.").
 	Set("COMMAND_DISABLED", "This command has been disabled globally by the bot owner.").
-	Set("COMMAND_MISSING_PERMS", "You are missing %s permission(s) to run this command.")
+	Set("COMMAND_MISSING_PERMS", "You are missing %s permission(s) to run this command.").
+	Set("CMD_PING_DESCRIPTION", "Pong! Responds with Bot latency.").
+	Set("CMD_HELP_DESCRIPTION", "Shows a list of all commands.").
+	Set("CMD_STATS_DESCRIPTION", "Stats for nerds.").
+	Set("CMD_INVITE_DESCRIPTION", "Invite me to your server!").
+	Set("CMD_ENABLE_DESCRIPTION", "Enables a disabled command.").
+	Set("CMD_DISABLE_DESCRIPTION", "Disables an enabled command.").
+	Set("CMD_GC_DESCRIPTION", "Forces a garbage collection cycle.").
+	Set("CMD_LANGUAGES_DESCRIPTION", "Reports missing and mismatched keys of the languages.").
+	Set("CATEGORY_GENERAL", "General").
+	Set("CATEGORY_OWNER", "Owner").
+	Set("COMMAND_HELP_UNKNOWN", "Unknown Command.").
+	Set("COMMAND_HELP_TITLE", "Command Help").
+	Set("COMMAND_HELP_NONE", "None").
+	Set("COMMAND_HELP_FLAGS", "Flags: {flags}").
+	Set("COMMAND_HELP_DETAILS", "**Name:** {name}\n**Description:** {description}\n**Category:** {category}\n**Aliases:** {aliases}\n**Usage:** {usage} \n{extra}").
+	Set("COMMAND_HELP_LIST_TITLE", "Commands").
+	Set("COMMAND_HELP_FOOTER", "For more info on a command use: {prefix}help <command>").
+	Set("COMMAND_HELP_MENU_TITLE", "Pick a category").
+	Set("COMMAND_STATS_TITLE", "Stats").
+	Set("COMMAND_STATS_GO_VERSION", "**Go Version**").
+	Set("COMMAND_STATS_DISCORDGO_VERSION", "**DiscordGo Version**").
+	Set("COMMAND_STATS_COMMANDS_TITLE", "**Command Stats**").
+	Set("COMMAND_STATS_COMMANDS", "Total Commands: {total}\nCommands Ran: {ran}").
+	Set("COMMAND_STATS_BOT_TITLE", "**Bot Stats**").
+	Set("COMMAND_STATS_BOT", "Guilds: {guilds}\nUsers: {users}\nChannels: {channels}\nUptime: {uptime}").
+	Set("COMMAND_STATS_MEMORY_TITLE", "**Memory Stats**").
+	Set("COMMAND_STATS_MEMORY", "Used: {used} / {sys}\nGarbage Collected: {collected}\nGC Cycles: {cycles}\nForced GC Cycles: {forced}\nLast GC: {lastGC}\nNext GC Target: {nextGC}\nGoroutines: {goroutines}").
+	Set("COMMAND_STATS_TECHNICAL_TITLE", "**Technical Info**").
+	Set("COMMAND_STATS_TECHNICAL", "CPU Cores: {cores}\nOS/Arch: {os}/{arch}").
+	Set("COMMAND_STATS_DISPATCHER_TITLE", "**Dispatcher**").
+	Set("COMMAND_STATS_DISPATCHER", "Workers: {workers}\nQueued: {queued} / {size}\nRunning: {running}\nProcessed: {processed}\nDropped: {dropped}").
+	Set("COMMAND_GC", "Forced Garbage Collection.\n  - Freed **{freed}**\n  - {objects} Objects Collected.\n  - Took **{took}**").
+	Set("COMMAND_LANGUAGES_NONE", "There are no languages to audit besides the default one.").
+	Set("COMMAND_LANGUAGES_TITLE", "{language} (compared to {reference})").
+	Set("COMMAND_LANGUAGES_OK", "Every key is translated.").
+	Set("COMMAND_LANGUAGES_MISSING", "Missing keys ({count})").
+	Set("COMMAND_LANGUAGES_EXTRA", "Extra keys ({count})").
+	Set("COMMAND_LANGUAGES_MISMATCHED", "Mismatched placeholders ({count})").
+	Set("ARGUMENT_REQUIRED", "The argument **{name}** is required.").
+	Set("ARGUMENT_INVALID_NUMBER", "**{name}** must be a valid number.").
+	Set("ARGUMENT_INVALID_MEMBER", "**{name}** must be a valid member mention or ID.").
+	Set("ARGUMENT_MEMBER_NOT_FOUND", "That member cannot be found in this server.").
+	Set("ARGUMENT_INVALID_USER", "**{name}** must be a valid user mention or ID.").
+	Set("ARGUMENT_USER_NOT_FOUND", "That user cannot be found.").
+	Set("ARGUMENT_INVALID_CHANNEL", "**{name}** must be a valid channel mention or ID.").
+	Set("ARGUMENT_CHANNEL_NOT_FOUND", "That channel cannot be found.").
+	Set("ARGUMENT_INVALID_LITERAL", "Literal argument must be **{name}**").
+	Set("ARGUMENT_INVALID_TYPE", "The argument type **{type}** is invalid.").
+	Set("PAGINATOR_FOOTER", "Page {page}/{total} {extra}").
+	Set("REPLY_TOO_LONG", "The response is too long, so it is attached as a file.").
+	Set("MENU_FOOTER", "React with the emoji of an option to select it.").
+	Set("MENU_FOOTER_MULTI", "React with the emojis of the options to select them then {confirm} to confirm.").
+	Set("PAGINATOR_FOOTER_ENDED", "Page {page}/{total} {extra} • Ended").
+	Set("PAGINATOR_JUMP", "Type the number of the page to go to. (1-{total})").
+	Set("DURATION_DAYS", "{n, plural, one {# day} other {# days}}").
+	Set("DURATION_HOURS", "{n, plural, one {# hour} other {# hours}}").
+	Set("DURATION_MINUTES", "{n, plural, one {# minute} other {# minutes}}").
+	Set("DURATION_SECONDS", "{n, plural, one {# second} other {# seconds}}").
+	Set("DURATION_MILLISECONDS", "{n, number}ms").
+	Set("DURATION_SEPARATOR", ", ").
+	Set("RELATIVE_NOW", "just now").
+	Set("RELATIVE_PAST", "{duration} ago").
+	Set("RELATIVE_FUTURE", "in {duration}")
+
+func init() {
+	// Permission names used in COMMAND_MISSING_PERMS.
+	for _, p := range helpers.PermissionList {
+		English.Set(p.Key, p.Name)
+	}
+}
@@ -0,0 +1,90 @@
+package gocto
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLocaleChain(t *testing.T) {
+	bot := &Bot{Languages: make(map[string]*Language)}
+	bot.AddLanguage(English).
+		AddLanguage(NewLanguage("pt")).
+		AddLanguage(NewLanguage("pt-BR")).
+		AddLanguage(NewLanguage("fr")).
+		AddLanguage(NewLanguage("ca").SetParent("es")).
+		AddLanguage(NewLanguage("es")).
+		SetDefaultLocale("en-US")
+
+	names := func(chain []*Language) string {
+		res := ""
+		for _, lang := range chain {
+			res += lang.Name + " "
+		}
+		return res
+	}
+
+	cases := map[string]string{
+		"pt-BR": "pt-BR pt en-US ",
+		"fr-CA": "fr en-US ",
+		"ca":    "ca es en-US ",
+		"en-US": "en-US ",
+		"xx":    "en-US ",
+	}
+	for name, expect := range cases {
+		if res := names(bot.LocaleChain(name)); res != expect {
+			t.Errorf("Expected LocaleChain(%q) to be %q but got %q", name, expect, res)
+		}
+	}
+
+	if lang := bot.FindLanguage("fr_CA"); lang == nil || lang.Name != "fr" {
+		t.Errorf("Expected FindLanguage(\"fr_CA\") to return fr")
+	}
+}
+
+func TestAuditLanguage(t *testing.T) {
+	ref := NewLanguage("en-US").
+		Set("A", "Hello").
+		Set("B", "Hello %s").
+		Set("C", "{count, plural, one {# apple} other {# apples}} for {name}").
+		Set("D", "Missing")
+	lang := NewLanguage("fr-FR").
+		Set("A", "Bonjour").
+		Set("B", "Bonjour %d").
+		Set("C", "{name} a {count, plural, one {# pomme} other {# pommes}}").
+		Set("E", "Extra")
+
+	audit := AuditLanguage(lang, ref)
+	if audit.OK() {
+		t.Error("Expected the audit to report problems")
+	}
+	expect := func(what string, res []string, keys ...string) {
+		if fmt.Sprint(res) != fmt.Sprint(keys) {
+			t.Errorf("Expected %s keys to be %v but got %v", what, keys, res)
+		}
+	}
+	expect("missing", audit.Missing, "D")
+	expect("extra", audit.Extra, "E")
+	expect("mismatched", audit.Mismatched, "B")
+}
+
+func TestKeyFields(t *testing.T) {
+	keys := make([]string, 200)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("COMMAND_SOMETHING_%d_DESCRIPTION", i)
+	}
+	values := keyFields(keys)
+	if len(values) < 2 {
+		t.Fatalf("Expected the keys to be spread over several fields, got %d", len(values))
+	}
+	joined := strings.Join(values, ", ")
+	for _, value := range values {
+		if utf8.RuneCountInString(value) > EmbedLimitFieldValue {
+			t.Errorf("Expected fields of at most %d characters, got %d", EmbedLimitFieldValue, utf8.RuneCountInString(value))
+		}
+	}
+	if strings.Count(joined, "`") != 400 {
+		t.Error("Expected every key to be listed once")
+	}
+}
@@ -0,0 +1,249 @@
+package gocto
+
+import (
+	"errors"
+	"github.com/jonas747/discordgo"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NumberEmojis are the emojis given to menu options without a custom emoji, in order.
+var NumberEmojis = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣", "9️⃣", "🔟"}
+
+const EmojiConfirm = "✅"
+
+// ErrMenuTimeout is returned by Menu.Run when nothing was selected before the timeout.
+var ErrMenuTimeout = errors.New("menu timed out")
+
+// ErrMenuDeleted is returned by Menu.Run when the menu's message was deleted before a selection.
+var ErrMenuDeleted = errors.New("menu message was deleted")
+
+// ErrMenuOptions is returned by Menu.Run when the menu has no options or more options than emojis.
+var ErrMenuOptions = errors.New("menu has no options or too many options")
+
+type MenuOption struct {
+	Emoji string // A unicode emoji or "name:id" for custom emojis.
+	Label string
+	Value interface{} // Any value to identify the option by.
+}
+
+// Menu sends an embed with options that the user selects with reactions.
+type Menu struct {
+	Session   *discordgo.Session
+	ChannelID int64
+	AuthorID  int64 // The user that can select, 0 for anyone.
+	Title     string
+	Template  func() *Embed
+	Options   []*MenuOption
+	Multi     bool          // Wether multiple options can be selected, confirmed with EmojiConfirm. (default: false)
+	Timeout   time.Duration // How long to wait for a selection. (default: 1 minute)
+	Delete    bool          // Wether to delete the message after a selection. (default: false)
+	Message   *discordgo.Message
+	Localize  func(key string, args ...interface{}) string // Localizes the menu's texts. (default: English)
+	Router    *ReactionRouter                              // Routes the reactions to the menu, nil to use its own. (default: bot.Reactions for NewMenuForContext)
+
+	ctx *CommandContext // The command the menu responds to, its message is tracked with the command's responses.
+}
+
+func NewMenu(session *discordgo.Session, channel, author int64) *Menu {
+	return &Menu{
+		Session:   session,
+		ChannelID: channel,
+		AuthorID:  author,
+		Template:  func() *Embed { return NewEmbed() },
+		Multi:     false,
+		Timeout:   time.Minute,
+		Delete:    false,
+		Localize: func(key string, args ...interface{}) string {
+			return English.Get(key, args...)
+		},
+	}
+}
+
+// NewMenuForContext creates a menu for the context's channel and author, localized in the context's locale.
+func NewMenuForContext(ctx *CommandContext) *Menu {
+	m := NewMenu(ctx.Session, ctx.Channel.ID, ctx.Author.ID)
+	m.Localize = ctx.Localize
+	m.Router = ctx.Bot.Reactions
+	m.Template = func() *Embed { return NewEmbed().SetColor(ctx.Bot.Color) }
+	m.ctx = ctx
+	return m
+}
+
+func (m *Menu) SetTitle(title string) *Menu {
+	m.Title = title
+	return m
+}
+
+func (m *Menu) SetTemplate(em func() *Embed) *Menu {
+	m.Template = em
+	return m
+}
+
+func (m *Menu) SetMulti(toggle bool) *Menu {
+	m.Multi = toggle
+	return m
+}
+
+func (m *Menu) SetTimeout(timeout time.Duration) *Menu {
+	m.Timeout = timeout
+	return m
+}
+
+func (m *Menu) SetDelete(toggle bool) *Menu {
+	m.Delete = toggle
+	return m
+}
+
+// AddOption adds an option with the next number emoji.
+func (m *Menu) AddOption(label string, value interface{}) *Menu {
+	emoji := ""
+	if len(m.Options) < len(NumberEmojis) {
+		emoji = NumberEmojis[len(m.Options)]
+	}
+	return m.AddEmojiOption(emoji, label, value)
+}
+
+// AddEmojiOption adds an option with a custom emoji.
+func (m *Menu) AddEmojiOption(emoji, label string, value interface{}) *Menu {
+	m.Options = append(m.Options, &MenuOption{Emoji: emoji, Label: label, Value: value})
+	return m
+}
+
+// option returns the option of the emoji, custom emojis can be given as "name:id" or by name.
+func (m *Menu) option(emoji *discordgo.Emoji) *MenuOption {
+	for _, option := range m.Options {
+		if option.Emoji == emoji.Name || option.Emoji == emoji.APIName() {
+			return option
+		}
+	}
+	return nil
+}
+
+func (m *Menu) embed() *discordgo.MessageEmbed {
+	lines := make([]string, len(m.Options))
+	for i, option := range m.Options {
+		lines[i] = option.Emoji + " " + option.Label
+	}
+	footer := "MENU_FOOTER"
+	if m.Multi {
+		footer = "MENU_FOOTER_MULTI"
+	}
+	return m.Template().
+		SetTitle(m.Title).
+		SetDescription(strings.Join(lines, "\n")).
+		SetFooter(m.Localize(footer, LocaleArgs{"confirm": EmojiConfirm})).
+		Build()
+}
+
+// Run sends the menu and blocks until the user selects, returning the selected options in the order they are listed.
+// Single select menus return the option as soon as it's reacted, multi select menus when EmojiConfirm is reacted.
+// Returns ErrMenuTimeout if the selection wasn't made in time, along with the options of a multi select menu that were
+// selected but not confirmed. Confirming a multi select menu without a selection returns no options.
+// Returns ErrMenuDeleted if the menu's message is deleted, e.g when an edit re-runs the command.
+func (m *Menu) Run() ([]*MenuOption, error) {
+	if len(m.Options) == 0 {
+		return nil, ErrMenuOptions
+	}
+	for _, option := range m.Options {
+		if option.Emoji == "" {
+			return nil, ErrMenuOptions
+		}
+	}
+
+	var msg *discordgo.Message
+	var err error
+	if m.ctx != nil {
+		// Tracked as an extra response so an edit re-running the command deletes it, which ends the menu.
+		msg, err = m.ctx.Send(MessageOptions{Embed: m.embed(), NoEdit: true})
+	} else {
+		msg, err = m.Session.ChannelMessageSendEmbed(m.ChannelID, m.embed())
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Message = msg
+
+	router := m.Router
+	if router == nil {
+		// Not attached to a bot, route the reactions of this menu alone.
+		router = NewReactionRouter()
+		defer router.AddHandlers(m.Session)()
+	}
+
+	var lock sync.Mutex
+	selected := make(map[*MenuOption]bool)
+	finished := make(chan struct{})
+	var once sync.Once
+	finish := func() { once.Do(func() { close(finished) }) }
+
+	unregister := router.Register(msg.ID, func(r *discordgo.MessageReaction, added bool) {
+		if m.Session.State.User != nil && r.UserID == m.Session.State.User.ID {
+			return
+		}
+		if m.AuthorID != 0 && r.UserID != m.AuthorID {
+			return
+		}
+		if m.Multi && r.Emoji.Name == EmojiConfirm && added {
+			finish()
+			return
+		}
+		option := m.option(&r.Emoji)
+		if option == nil {
+			return
+		}
+		lock.Lock()
+		selected[option] = added
+		lock.Unlock()
+		if !m.Multi && added {
+			finish()
+		}
+	})
+	defer unregister()
+	deleted := make(chan struct{})
+	defer router.OnDelete(msg.ID, func() { close(deleted) })()
+
+	go func() {
+		for _, option := range m.Options {
+			select {
+			case <-finished:
+				return
+			default:
+			}
+			m.Session.MessageReactionAdd(m.ChannelID, msg.ID, option.Emoji)
+		}
+		if m.Multi {
+			m.Session.MessageReactionAdd(m.ChannelID, msg.ID, EmojiConfirm)
+		}
+	}()
+
+	timedOut := false
+	select {
+	case <-finished:
+	case <-time.After(m.Timeout):
+		timedOut = true
+	case <-deleted:
+		finish()
+		return nil, ErrMenuDeleted
+	}
+
+	if m.Delete {
+		m.Session.ChannelMessageDelete(m.ChannelID, msg.ID)
+	} else {
+		m.Session.MessageReactionsRemoveAll(m.ChannelID, msg.ID)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	var result []*MenuOption
+	for _, option := range m.Options {
+		if selected[option] {
+			result = append(result, option)
+		}
+	}
+	if timedOut {
+		return result, ErrMenuTimeout
+	}
+	return result, nil
+}
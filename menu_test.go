@@ -0,0 +1,30 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"testing"
+)
+
+func TestMenuOptions(t *testing.T) {
+	m := NewMenu(nil, 1, 2).
+		AddOption("one", 1).
+		AddEmojiOption("custom:1234", "custom", 2).
+		AddOption("three", 3)
+
+	if m.Options[0].Emoji != NumberEmojis[0] || m.Options[2].Emoji != NumberEmojis[2] {
+		t.Errorf("Expected number emojis in order but got %q and %q", m.Options[0].Emoji, m.Options[2].Emoji)
+	}
+	if option := m.option(&discordgo.Emoji{Name: NumberEmojis[0]}); option == nil || option.Value != 1 {
+		t.Errorf("Expected the first option but got %+v", option)
+	}
+	if option := m.option(&discordgo.Emoji{Name: "custom", ID: 1234}); option == nil || option.Value != 2 {
+		t.Errorf("Expected the custom emoji option but got %+v", option)
+	}
+	if option := m.option(&discordgo.Emoji{Name: "🔟"}); option != nil {
+		t.Errorf("Expected no option but got %+v", option)
+	}
+
+	if _, err := NewMenu(nil, 1, 2).Run(); err != ErrMenuOptions {
+		t.Errorf("Expected ErrMenuOptions for an empty menu but got %v", err)
+	}
+}
@@ -0,0 +1,447 @@
+package gocto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// LocaleArgs are named arguments for a localized message.
+// Pass them as the only argument to ReplyLocale, Get, Format etc. to fill {name} placeholders.
+type LocaleArgs map[string]interface{}
+
+// ErrLocaleNoKey is returned by Language.Format when the key is not localized.
+var ErrLocaleNoKey = errors.New("no localization found for the key")
+
+// LocaleError is reported when a localized message cannot be resolved or formatted.
+type LocaleError struct {
+	Locale string // The name of the language.
+	Key    string // The key being localized.
+	Err    error  // The underlying error.
+}
+
+func (err *LocaleError) Error() string {
+	return fmt.Sprintf("locale %s: key %s: %v", err.Locale, err.Key, err.Err)
+}
+
+func (err *LocaleError) Unwrap() error {
+	return err.Err
+}
+
+// FormatMessage formats a localized message for lang.
+//
+// Messages may contain placeholders in an ICU-like syntax:
+//
+//	{name}                                                  the named argument, or the positional one e.g {0}
+//	{count, number}                                         the number formatted with the language's separators
+//	{count, plural, =0 {none} one {# item} other {# items}} plural branches, # is replaced by the formatted number
+//	{gender, select, male {he} female {she} other {they}}   select branches
+//
+// An apostrophe quotes literal braces and # e.g '{' and two apostrophes produce a single one.
+//
+// Messages without placeholders are treated as printf format strings when there are positional arguments
+// so languages written with %s/%d keep working, without arguments they are returned as is e.g "100% done".
+//
+// On error the partially formatted message is returned along with the error.
+func FormatMessage(lang *Language, message string, args ...interface{}) (string, error) {
+	return formatMessage(lang, nil, message, args...)
+}
+
+// formatMessage is FormatMessage with {@KEY} placeholders resolved by localize, which is called with the same arguments.
+// They are not supported if localize is nil.
+func formatMessage(lang *Language, localize func(key string, args ...interface{}) string, message string, args ...interface{}) (string, error) {
+	f := &messageFormatter{lang: lang, localize: localize}
+	if len(args) == 1 {
+		if named, ok := args[0].(LocaleArgs); ok {
+			f.named = named
+		}
+	}
+	if f.named == nil {
+		f.positional = args
+	}
+
+	res, err := f.format(message, "")
+	if err != nil {
+		return res, err
+	}
+
+	if !f.used && len(f.positional) > 0 {
+		// Legacy printf style message, the arguments are checked against the verbs beforehand
+		// as the formatted result may contain anything the arguments do.
+		if n, ok := countVerbs(res); !ok || n != len(f.positional) {
+			return res, fmt.Errorf("bad format verbs or arguments in %q", message)
+		}
+		res = fmt.Sprintf(res, f.positional...)
+	}
+	return res, nil
+}
+
+// countVerbs returns how many arguments the printf format uses, explicit argument indexes included.
+// Returns false if a verb is incomplete.
+func countVerbs(format string) (int, bool) {
+	used, next := 0, 0
+	// index parses an explicit argument index e.g [2] at i, returning the position after it.
+	index := func(i int) (int, bool) {
+		if i >= len(format) || format[i] != '[' {
+			return i, true
+		}
+		end := strings.IndexByte(format[i:], ']')
+		if end < 0 {
+			return i, false
+		}
+		n, err := strconv.Atoi(format[i+1 : i+end])
+		if err != nil || n < 1 {
+			return i, false
+		}
+		next = n - 1
+		return i + end + 1, true
+	}
+	consume := func() {
+		next++
+		if next > used {
+			used = next
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(format) && strings.IndexByte("#0+- ", format[i]) >= 0 {
+			i++
+		}
+		var ok bool
+		// Width and precision, either of them can be an argument with *.
+		for _, precision := range []bool{false, true} {
+			if precision {
+				if i >= len(format) || format[i] != '.' {
+					break
+				}
+				i++
+			}
+			if i, ok = index(i); !ok {
+				return used, false
+			}
+			if i < len(format) && format[i] == '*' {
+				consume()
+				i++
+			}
+			for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+				i++
+			}
+		}
+		if i, ok = index(i); !ok || i >= len(format) {
+			return used, false
+		}
+		if format[i] == '%' {
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+		consume()
+	}
+	return used, true
+}
+
+type messageFormatter struct {
+	lang       *Language
+	positional []interface{}
+	named      LocaleArgs
+	used       bool                                         // Wether any placeholder was used.
+	localize   func(key string, args ...interface{}) string // Resolves {@KEY} placeholders, nil if they aren't supported.
+}
+
+// format formats pattern, pound is what # is replaced with inside a plural branch.
+func (f *messageFormatter) format(pattern string, pound string) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				out.WriteByte('\'')
+				i++
+				continue
+			}
+			if i+1 < len(pattern) && strings.IndexByte("{}#", pattern[i+1]) != -1 {
+				end := strings.IndexByte(pattern[i+1:], '\'')
+				if end == -1 {
+					out.WriteString(pattern[i+1:])
+					return out.String(), nil
+				}
+				out.WriteString(pattern[i+1 : i+1+end])
+				i += end + 1
+				continue
+			}
+			out.WriteByte(c)
+		case c == '#' && pound != "":
+			out.WriteString(pound)
+		case c == '{':
+			end := matchingBrace(pattern, i)
+			if end == -1 {
+				out.WriteString(pattern[i:])
+				return out.String(), fmt.Errorf("unclosed placeholder at offset %d", i)
+			}
+			res, err := f.placeholder(pattern[i+1 : end])
+			if err != nil {
+				out.WriteString(pattern[i : end+1])
+				return out.String(), err
+			}
+			out.WriteString(res)
+			i = end
+		case c == '}':
+			return out.String(), fmt.Errorf("unexpected '}' at offset %d", i)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String(), nil
+}
+
+// matchingBrace returns the index of the brace closing the one at start or -1.
+func matchingBrace(pattern string, start int) int {
+	depth := 0
+	quoted := false
+	for i := start; i < len(pattern); i++ {
+		c := pattern[i]
+		if c == '\'' {
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				i++
+				continue
+			}
+			if quoted || (i+1 < len(pattern) && strings.IndexByte("{}#", pattern[i+1]) != -1) {
+				quoted = !quoted
+			}
+			continue
+		}
+		if quoted {
+			continue
+		}
+		if c == '{' {
+			depth++
+		} else if c == '}' {
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (f *messageFormatter) arg(name string) (interface{}, error) {
+	if f.named != nil {
+		if v, ok := f.named[name]; ok {
+			return v, nil
+		}
+	}
+	if idx, err := strconv.Atoi(name); err == nil && idx >= 0 && idx < len(f.positional) {
+		return f.positional[idx], nil
+	}
+	return nil, fmt.Errorf("missing argument %q", name)
+}
+
+func (f *messageFormatter) placeholder(body string) (string, error) {
+	f.used = true
+	if f.localize != nil && strings.HasPrefix(body, "@") {
+		if f.named != nil {
+			return f.localize(strings.TrimSpace(body[1:]), f.named), nil
+		}
+		return f.localize(strings.TrimSpace(body[1:]), f.positional...), nil
+	}
+	parts := strings.SplitN(body, ",", 3)
+	name := strings.TrimSpace(parts[0])
+	v, err := f.arg(name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(parts) == 1 {
+		return fmt.Sprint(v), nil
+	}
+
+	kind := strings.TrimSpace(parts[1])
+	if kind == "number" {
+		return f.lang.FormatNumber(v), nil
+	}
+	if len(parts) < 3 {
+		return "", fmt.Errorf("placeholder %q has no branches", name)
+	}
+	branches, err := parseBranches(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("placeholder %q: %v", name, err)
+	}
+
+	switch kind {
+	case "plural":
+		n, ok := toFloat(v)
+		if !ok {
+			return "", fmt.Errorf("argument %q must be a number for plural, got %T", name, v)
+		}
+		pound := f.lang.FormatNumber(v)
+		if branch, ok := branches["="+strconv.FormatFloat(n, 'f', -1, 64)]; ok {
+			return f.format(branch, pound)
+		}
+		if branch, ok := branches[f.lang.PluralCategory(n)]; ok {
+			return f.format(branch, pound)
+		}
+		if branch, ok := branches["other"]; ok {
+			return f.format(branch, pound)
+		}
+		return "", fmt.Errorf("placeholder %q has no \"other\" branch", name)
+	case "select":
+		if branch, ok := branches[fmt.Sprint(v)]; ok {
+			return f.format(branch, "")
+		}
+		if branch, ok := branches["other"]; ok {
+			return f.format(branch, "")
+		}
+		return "", fmt.Errorf("placeholder %q has no \"other\" branch", name)
+	default:
+		return "", fmt.Errorf("placeholder %q has unknown type %q", name, kind)
+	}
+}
+
+// parseBranches parses "key {message} key {message}" pairs.
+func parseBranches(s string) (map[string]string, error) {
+	branches := make(map[string]string)
+	for {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return branches, nil
+		}
+		open := strings.IndexByte(s, '{')
+		if open == -1 {
+			return nil, fmt.Errorf("expected '{' after %q", s)
+		}
+		key := strings.TrimSpace(s[:open])
+		if key == "" {
+			return nil, errors.New("branch without a selector")
+		}
+		end := matchingBrace(s, open)
+		if end == -1 {
+			return nil, fmt.Errorf("unclosed branch %q", key)
+		}
+		branches[key] = s[open+1 : end]
+		s = s[end+1:]
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// ----- Plural rules -----
+
+// PluralRule returns the plural category ("zero", "one", "two", "few", "many" or "other") for n.
+type PluralRule func(n float64) string
+
+// PluralRuleOneOther is used by English, German, Spanish etc. 1 is "one", everything else is "other".
+func PluralRuleOneOther(n float64) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// PluralRuleFrench is used by French and Portuguese. 0 and 1 are "one".
+func PluralRuleFrench(n float64) string {
+	if n >= 0 && n < 2 {
+		return "one"
+	}
+	return "other"
+}
+
+// PluralRuleSlavic is used by Russian and Ukrainian.
+func PluralRuleSlavic(n float64) string {
+	if n != float64(int64(n)) {
+		return "other"
+	}
+	i := int64(n)
+	if i < 0 {
+		i = -i
+	}
+	switch {
+	case i%10 == 1 && i%100 != 11:
+		return "one"
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return "few"
+	default:
+		return "many"
+	}
+}
+
+// PluralRulePolish is used by Polish.
+func PluralRulePolish(n float64) string {
+	if n != float64(int64(n)) {
+		return "other"
+	}
+	i := int64(n)
+	switch {
+	case i == 1:
+		return "one"
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return "few"
+	default:
+		return "many"
+	}
+}
+
+// PluralRuleNone is used by languages without plural forms e.g Japanese, Chinese and Korean.
+func PluralRuleNone(n float64) string {
+	return "other"
+}
+
+// PluralRules maps base language tags to their plural rules.
+// NewLanguage picks the rule from here, languages not listed use PluralRuleOneOther.
+var PluralRules = map[string]PluralRule{
+	"fr": PluralRuleFrench,
+	"pt": PluralRuleFrench,
+	"ru": PluralRuleSlavic,
+	"uk": PluralRuleSlavic,
+	"pl": PluralRulePolish,
+	"ja": PluralRuleNone,
+	"zh": PluralRuleNone,
+	"ko": PluralRuleNone,
+}
+
+// baseTag returns the lowercase primary language subtag e.g pt-BR -> pt
+func baseTag(name string) string {
+	return strings.ToLower(strings.SplitN(strings.Replace(name, "_", "-", -1), "-", 2)[0])
+}
+
+func pluralRuleFor(name string) PluralRule {
+	if rule, ok := PluralRules[baseTag(name)]; ok {
+		return rule
+	}
+	return PluralRuleOneOther
+}
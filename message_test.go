@@ -0,0 +1,103 @@
+package gocto
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCountVerbs(t *testing.T) {
+	cases := map[string]int{
+		"no verbs":          0,
+		"100%% %s":          1,
+		"%s %-5d %.2f %x":   4,
+		"%*d %.*f":          4,
+		"%[2]s %[1]s":       2,
+		"%[1]s %[1]s %s":    2,
+		"%5.[2]*[1]d and ü": 2,
+		"%v ü %s":           2,
+	}
+	for format, expect := range cases {
+		if n, ok := countVerbs(format); !ok || n != expect {
+			t.Errorf("Expected countVerbs(%q) to return %d but got %d, %v", format, expect, n, ok)
+		}
+	}
+	for _, bad := range []string{"trailing %", "%[x]d", "%[2"} {
+		if _, ok := countVerbs(bad); ok {
+			t.Errorf("Expected countVerbs(%q) to fail", bad)
+		}
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	en := NewLanguage("en-US")
+	fr := NewLanguage("fr-FR")
+	cooldown := "{seconds, plural, =0 {now} one {in # second} other {in # seconds}}"
+
+	cases := []struct {
+		lang    *Language
+		message string
+		args    []interface{}
+		expect  string
+	}{
+		{en, "Hello {name}!", []interface{}{LocaleArgs{"name": "World"}}, "Hello World!"},
+		{en, "{1} before {0}", []interface{}{"a", "b"}, "b before a"},
+		{en, cooldown, []interface{}{LocaleArgs{"seconds": 0}}, "now"},
+		{en, cooldown, []interface{}{LocaleArgs{"seconds": 1}}, "in 1 second"},
+		{en, cooldown, []interface{}{LocaleArgs{"seconds": 5}}, "in 5 seconds"},
+		{fr, "{n, plural, one {# seconde} other {# secondes}}", []interface{}{LocaleArgs{"n": 1.5}}, "1,5 seconde"},
+		{en, "{g, select, male {he} female {she} other {they}}", []interface{}{LocaleArgs{"g": "female"}}, "she"},
+		{en, "{g, select, male {he} other {they}}", []interface{}{LocaleArgs{"g": "x"}}, "they"},
+		{en, "'{literal}' and it''s #", []interface{}{LocaleArgs{}}, "{literal} and it's #"},
+		{en, "Legacy %s %d", []interface{}{"printf", 2}, "Legacy printf 2"},
+	}
+
+	for _, c := range cases {
+		res, err := FormatMessage(c.lang, c.message, c.args...)
+		if err != nil {
+			t.Errorf("FormatMessage(%q) returned an error: %v", c.message, err)
+		}
+		if res != c.expect {
+			t.Errorf("Expected FormatMessage(%q) to return %q but got %q", c.message, c.expect, res)
+		}
+	}
+
+	for _, bad := range []string{"{missing}", "{n, plural, one {#}}", "{n, plural, other {#}", "{n, bogus}"} {
+		if _, err := FormatMessage(en, bad, LocaleArgs{"n": 2}); err == nil {
+			t.Errorf("Expected FormatMessage(%q) to return an error", bad)
+		}
+	}
+	if _, err := FormatMessage(en, "Legacy %d %d", 1); err == nil {
+		t.Error("Expected a missing printf argument to return an error")
+	}
+	if _, err := FormatMessage(en, "Legacy %d", 1, 2); err == nil {
+		t.Error("Expected an extra printf argument to return an error")
+	}
+	if res, err := FormatMessage(en, "Said %q", "%!d(MISSING)"); err != nil || res != `Said "%!d(MISSING)"` {
+		t.Errorf("Expected arguments to be formatted as is but got %q, %v", res, err)
+	}
+	if res, err := FormatMessage(en, "100% done"); err != nil || res != "100% done" {
+		t.Errorf("Expected a message without arguments to be returned as is but got %q, %v", res, err)
+	}
+}
+
+func TestLanguageFormat(t *testing.T) {
+	lang := NewLanguage("en-US").Set("KEY", "{n} items")
+	if _, err := lang.Format("NOPE"); !errors.Is(err, ErrLocaleNoKey) {
+		t.Errorf("Expected ErrLocaleNoKey but got %v", err)
+	}
+	if res := lang.Get("KEY", LocaleArgs{"n": 3}); res != "3 items" {
+		t.Errorf("Expected \"3 items\" but got %q", res)
+	}
+}
+
+func TestPluralRules(t *testing.T) {
+	ru := NewLanguage("ru-RU")
+	for n, expect := range map[float64]string{1: "one", 3: "few", 5: "many", 11: "many", 21: "one", 22: "few"} {
+		if res := ru.PluralCategory(n); res != expect {
+			t.Errorf("Expected ru plural category of %v to be %s but got %s", n, expect, res)
+		}
+	}
+	if res := NewLanguage("pt-BR").PluralCategory(0); res != "one" {
+		t.Errorf("Expected pt plural category of 0 to be one but got %s", res)
+	}
+}
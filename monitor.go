@@ -5,8 +5,11 @@ import (
 	"github.com/Noctember/gocto/helpers"
 	"github.com/jonas747/discordgo"
 	"regexp"
+	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type MonitorHandler func(bot *Bot, ctx *MonitorContext)
@@ -20,6 +23,11 @@ type Monitor struct {
 	IgnoreBots     bool
 	IgnoreSelf     bool
 	IgnoreEdits    bool
+	Priority       int            // Monitors with a higher priority run first, equal ones run in the order they were added. (default: 0)
+	Async          bool           // Wether to run in a separate goroutine, synchronous monitors can stop lower priority ones from running. (default: true)
+	Filters        MonitorFilters // Scopes and content triggers checked before the monitor runs. (default: none)
+	MaxPanics      int32          // Disables the monitor after panicking this many times in a row, 0 to never disable it. (default: 0)
+	panics         int32
 }
 
 func (m *Monitor) AllowBots() *Monitor {
@@ -47,6 +55,71 @@ func (m *Monitor) AllowEdits() *Monitor {
 	return m
 }
 
+func (m *Monitor) SetPriority(priority int) *Monitor {
+	m.Priority = priority
+	return m
+}
+
+// SetAsync sets wether the monitor runs in its own goroutine,
+// the next monitors wait for synchronous monitors to finish.
+func (m *Monitor) SetAsync(toggle bool) *Monitor {
+	m.Async = toggle
+	return m
+}
+
+// SetMaxPanics disables the monitor after it panics max times in a row, 0 to never disable it.
+func (m *Monitor) SetMaxPanics(max int32) *Monitor {
+	m.MaxPanics = max
+	return m
+}
+
+// MonitorError is passed to the ErrorHandler when a monitor or an event monitor panics.
+type MonitorError struct {
+	Err          interface{}        // The value passed to panic()
+	Name         string             // The name of the monitor.
+	Event        string             // The event of an event monitor, empty for message monitors.
+	Message      *discordgo.Message // The message the monitor was ran on, nil for event monitors.
+	Context      *MonitorContext    // The context of a message monitor.
+	EventContext *EventContext      // The context of an event monitor.
+	Data         interface{}        // The event an event monitor was ran on e.g *discordgo.MessageReactionAdd
+	Stack        []byte
+	Disabled     bool // True if the monitor was disabled for reaching its MaxPanics.
+}
+
+func (err *MonitorError) Error() string {
+	if err.Event != "" {
+		return fmt.Sprintf("event monitor %s (%s): %v", err.Name, err.Event, err.Err)
+	}
+	return fmt.Sprintf("monitor %s: %v", err.Name, err.Err)
+}
+
+// recoverMonitor recovers a panic of a monitor and reports it to the ErrorHandler as merr,
+// the monitor is disabled under monitorLock once it panicked maxPanics times in a row.
+// Must be deferred, panics is reset when the monitor returned normally.
+func (bot *Bot) recoverMonitor(merr *MonitorError, panics *int32, maxPanics int32, enabled *bool) {
+	err := recover()
+	if err == nil {
+		atomic.StoreInt32(panics, 0)
+		return
+	}
+	merr.Err = err
+	merr.Stack = debug.Stack()
+	if n := atomic.AddInt32(panics, 1); maxPanics > 0 && n >= maxPanics {
+		bot.monitorLock.Lock()
+		*enabled = false
+		bot.monitorLock.Unlock()
+		merr.Disabled = true
+	}
+	bot.ErrorHandler(bot, merr)
+}
+
+// runMonitor runs the monitor, recovering and reporting panics as a *MonitorError.
+func runMonitor(bot *Bot, monitor *Monitor, ctx *MonitorContext) {
+	merr := &MonitorError{Name: monitor.Name, Message: ctx.Message, Context: ctx}
+	defer bot.recoverMonitor(merr, &monitor.panics, monitor.MaxPanics, &monitor.Enabled)
+	monitor.Run(bot, ctx)
+}
+
 func NewMonitor(name string, monitor MonitorHandler) *Monitor {
 	return &Monitor{
 		Name:           name,
@@ -57,6 +130,8 @@ func NewMonitor(name string, monitor MonitorHandler) *Monitor {
 		IgnoreBots:     true,
 		IgnoreSelf:     true,
 		IgnoreEdits:    true,
+		Priority:       0,
+		Async:          true,
 	}
 }
 
@@ -68,6 +143,26 @@ type MonitorContext struct {
 	Monitor *Monitor
 	Guild   *discordgo.Guild
 	Bot     *Bot
+	stopped *int32
+}
+
+// StopPropagation stops the monitors with a lower priority from running on this message,
+// e.g a word filter can stop the command handler from running on a message it deleted.
+// Only synchronous monitors can reliably stop the next ones as asynchronous ones don't hold them.
+func (ctx *MonitorContext) StopPropagation() {
+	atomic.StoreInt32(ctx.stopped, 1)
+}
+
+// IsPropagationStopped returns true if a monitor called StopPropagation.
+func (ctx *MonitorContext) IsPropagationStopped() bool {
+	return atomic.LoadInt32(ctx.stopped) == 1
+}
+
+// monitorEnabled reads monitor.Enabled under monitorLock, monitors that panic too often are disabled from the goroutine they ran on.
+func (bot *Bot) monitorEnabled(monitor *Monitor) bool {
+	bot.monitorLock.RLock()
+	defer bot.monitorLock.RUnlock()
+	return monitor.Enabled
 }
 
 func monitorHandler(bot *Bot, m *discordgo.Message, edit bool) {
@@ -82,8 +177,13 @@ func monitorHandler(bot *Bot, m *discordgo.Message, edit bool) {
 		}
 	}()
 
-	for _, monitor := range bot.Monitors {
-		if !monitor.Enabled {
+	stopped := new(int32)
+	for _, monitor := range bot.sortedMonitors() {
+		if atomic.LoadInt32(stopped) == 1 {
+			return
+		}
+
+		if !bot.monitorEnabled(monitor) {
 			continue
 		}
 
@@ -121,7 +221,11 @@ func monitorHandler(bot *Bot, m *discordgo.Message, edit bool) {
 			continue
 		}
 
-		go monitor.Run(bot, &MonitorContext{
+		if !monitor.Filters.Match(m, channel) {
+			continue
+		}
+
+		mctx := &MonitorContext{
 			Session: bot.Session,
 			Message: m,
 			Author:  m.Author,
@@ -129,7 +233,15 @@ func monitorHandler(bot *Bot, m *discordgo.Message, edit bool) {
 			Monitor: monitor,
 			Guild:   guild,
 			Bot:     bot,
-		})
+			stopped: stopped,
+		}
+
+		if monitor.Async {
+			monitor := monitor
+			bot.dispatch(m.GuildID, m.ChannelID, func() { runMonitor(bot, monitor, mctx) })
+		} else {
+			runMonitor(bot, monitor, mctx)
+		}
 	}
 }
 
@@ -141,6 +253,11 @@ func monitorListener(bot *Bot) func(s *discordgo.Session, m *discordgo.MessageCr
 
 func monitorEditListener(bot *Bot) func(s *discordgo.Session, m *discordgo.MessageUpdate) {
 	return func(s *discordgo.Session, m *discordgo.MessageUpdate) {
+		// Cancel the deletion of edited messages before the monitors run so a command re-ran by the edit schedules it again after.
+		// Embeds being unfurled send updates without an author and the bot's own edits restart their delay themselves.
+		if m.Author != nil && (s.State.User == nil || m.Author.ID != s.State.User.ID) {
+			bot.Deletions.Cancel(m.ID)
+		}
 		monitorHandler(bot, m.Message, true)
 	}
 }
@@ -202,7 +319,20 @@ func CommandHandlerMonitor(bot *Bot, ctx *MonitorContext) {
 		args = split[1:]
 	}
 
+	// Unknown locales fallback through the chain down to the default locale.
+	// The locale handler is only called when needed as it may be expensive.
+	var locales []*Language
+	resolveLocales := func() []*Language {
+		if locales == nil {
+			locales = bot.LocaleChain(bot.Language(bot, ctx.Message, ctx.Channel.Type == discordgo.ChannelTypeDM))
+		}
+		return locales
+	}
+
 	cmd := bot.GetCommand(input)
+	if cmd == nil && len(bot.localeAliases) > 0 {
+		cmd = bot.GetLocalizedCommand(input, resolveLocales())
+	}
 	if cmd == nil {
 		return
 	}
@@ -221,17 +351,8 @@ func CommandHandlerMonitor(bot *Bot, ctx *MonitorContext) {
 		InvokedName: input,
 	}
 
-	lang := bot.Language(bot, ctx.Message, ctx.Channel.Type == discordgo.ChannelTypeDM)
-	locale, ok := bot.Languages[lang]
-
-	// Shouldn't happen unless the user made a mistake returning an invalid string, let's help them find the problem.
-	if !ok {
-		fmt.Printf("WARNING: bot.Language handler returned a non-existent language '%s' (command execution aborted)\n", lang)
-		return
-	}
-
-	// Set the context's locale.
-	cctx.Locale = locale
+	cctx.Locales = resolveLocales()
+	cctx.Locale = cctx.Locales[0]
 
 	// Validations.
 	if !cmd.Enabled {
@@ -243,7 +364,9 @@ func CommandHandlerMonitor(bot *Bot, ctx *MonitorContext) {
 	}
 
 	if cmd.RequiredPermissions != 0 && !PermissionsForMember(ctx.Guild, cctx.Member(int64(ctx.Author.ID))).Has(cmd.RequiredPermissions) {
-		cctx.ReplyLocale("COMMAND_MISSING_PERMS", helpers.GetPermissionsText(cmd.RequiredPermissions))
+		cctx.ReplyLocale("COMMAND_MISSING_PERMS", helpers.GetPermissionsTextFunc(cmd.RequiredPermissions, func(p helpers.Permission) string {
+			return cctx.Localize(p.Key)
+		}))
 		return
 	}
 
@@ -264,7 +387,10 @@ func CommandHandlerMonitor(bot *Bot, ctx *MonitorContext) {
 
 	canRun, after := bot.CheckCooldown(ctx.Author.ID, cmd.Name, cmd.Cooldown)
 	if !canRun {
-		cctx.ReplyLocale("COMMAND_COOLDOWN", after)
+		cctx.ReplyLocale("COMMAND_COOLDOWN", LocaleArgs{
+			"seconds":  after,
+			"duration": cctx.FormatDuration(time.Duration(after) * time.Second),
+		})
 		return
 	}
 
@@ -272,7 +398,11 @@ func CommandHandlerMonitor(bot *Bot, ctx *MonitorContext) {
 
 	defer func() {
 		if cmd.DeleteAfter {
+			// Forget the responses first so deleting the command doesn't delete them with DeleteResponses.
+			bot.Responses.Delete(ctx.Message.ID)
 			ctx.Session.ChannelMessageDelete(ctx.Channel.ID, ctx.Message.ID)
+		} else if cmd.ExpireTrigger && cmd.ResponseTTL > 0 {
+			bot.Deletions.Schedule(ctx.Channel.ID, ctx.Message.ID, cmd.ResponseTTL)
 		}
 		if err := recover(); err != nil {
 			bot.ErrorHandler(bot, &CommandError{Err: err, Context: cctx})
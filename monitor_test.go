@@ -0,0 +1,159 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"sync"
+	"testing"
+)
+
+func TestMonitorOrder(t *testing.T) {
+	bot := &Bot{Monitors: make(map[string]*Monitor)}
+	noop := func(bot *Bot, ctx *MonitorContext) {}
+	bot.AddMonitor(NewMonitor("a", noop)).
+		AddMonitor(NewMonitor("b", noop).SetPriority(10)).
+		AddMonitor(NewMonitor("c", noop)).
+		AddMonitor(NewMonitor("d", noop).SetPriority(-1)).
+		AddMonitor(NewMonitor("a", noop).SetPriority(5)) // Replaces the first "a"
+
+	order := ""
+	for _, m := range bot.sortedMonitors() {
+		order += m.Name
+	}
+	if order != "bacd" {
+		t.Errorf("Expected monitors to run in the order \"bacd\" but got %q", order)
+	}
+}
+
+func TestMonitorFilters(t *testing.T) {
+	noop := func(bot *Bot, ctx *MonitorContext) {}
+	channel := &discordgo.Channel{ID: 2, Type: discordgo.ChannelTypeGuildText}
+	msg := func(content string) *discordgo.Message {
+		return &discordgo.Message{GuildID: 1, ChannelID: 2, Content: content, Author: &discordgo.User{ID: 3}}
+	}
+
+	cases := []struct {
+		monitor *Monitor
+		content string
+		match   bool
+	}{
+		{NewMonitor("a", noop), "anything", true},
+		{NewMonitor("a", noop).InGuilds(1), "", true},
+		{NewMonitor("a", noop).InGuilds(5), "", false},
+		{NewMonitor("a", noop).IgnoreChannels(2), "", false},
+		{NewMonitor("a", noop).ForUsers(3, 4), "", true},
+		{NewMonitor("a", noop).IgnoreUsers(3), "", false},
+		{NewMonitor("a", noop).InChannelTypes(discordgo.ChannelTypeDM), "", false},
+		{NewMonitor("a", noop).SetKeywords("hello", "hi"), "Hi there", true},
+		{NewMonitor("a", noop).SetKeywords("hello", "hi"), "this is it", false},
+		{NewMonitor("a", noop).SetKeywords("café"), "Un CAFÉ, svp", true},
+		{NewMonitor("a", noop).SetKeywords("caf"), "un café", false},
+		{NewMonitor("a", noop).SetKeywords("über"), "überall", false},
+		{&Monitor{Filters: MonitorFilters{Keywords: []string{"naïve"}}}, "so naïve!", true},
+		{NewMonitor("a", noop).SetTrigger("^ping$"), "ping", true},
+		{NewMonitor("a", noop).SetTrigger("^ping$"), "pong", false},
+		{NewMonitor("a", noop).SetAttachmentsOnly(true), "file", false},
+		{NewMonitor("a", noop).SetMinLength(5), "héllo", true},
+		{NewMonitor("a", noop).SetMinLength(6), "héllo", false},
+	}
+
+	for i, c := range cases {
+		if res := c.monitor.Filters.Match(msg(c.content), channel); res != c.match {
+			t.Errorf("Case %d (%q): expected %v but got %v", i, c.content, c.match, res)
+		}
+	}
+}
+
+func TestMonitorPanics(t *testing.T) {
+	var errs []*MonitorError
+	bot := &Bot{ErrorHandler: func(_ *Bot, err interface{}) {
+		errs = append(errs, err.(*MonitorError))
+	}}
+	fail := true
+	monitor := NewMonitor("unstable", func(bot *Bot, ctx *MonitorContext) {
+		if fail {
+			panic("oops")
+		}
+	}).SetMaxPanics(2)
+	ctx := &MonitorContext{Message: &discordgo.Message{}}
+
+	runMonitor(bot, monitor, ctx)
+	fail = false
+	runMonitor(bot, monitor, ctx) // Resets the count.
+	fail = true
+	runMonitor(bot, monitor, ctx)
+	if !monitor.Enabled {
+		t.Fatal("Expected the monitor to still be enabled")
+	}
+	runMonitor(bot, monitor, ctx)
+	if monitor.Enabled {
+		t.Fatal("Expected the monitor to be disabled")
+	}
+
+	if len(errs) != 3 {
+		t.Fatalf("Expected 3 errors but got %d", len(errs))
+	}
+	if errs[0].Name != "unstable" || errs[0].Err != "oops" || len(errs[0].Stack) == 0 || errs[0].Disabled {
+		t.Errorf("Unexpected error %+v", errs[0])
+	}
+	if !errs[2].Disabled {
+		t.Error("Expected the last error to be marked as disabled")
+	}
+}
+
+func TestEventMonitorPanics(t *testing.T) {
+	var errs []*MonitorError
+	bot := &Bot{ErrorHandler: func(_ *Bot, err interface{}) {
+		errs = append(errs, err.(*MonitorError))
+	}}
+	monitor := &EventMonitor{Name: "unstable", Event: EventReactionAdd, Enabled: true, MaxPanics: 2,
+		run: func(ctx *EventContext, event interface{}) {
+			panic("oops")
+		}}
+	ctx := &EventContext{Bot: bot, Monitor: monitor}
+	data := &discordgo.MessageReactionAdd{}
+
+	runEventMonitor(monitor, ctx, data)
+	if !bot.eventMonitorEnabled(monitor) {
+		t.Fatal("Expected the monitor to still be enabled")
+	}
+	runEventMonitor(monitor, ctx, data)
+	if bot.eventMonitorEnabled(monitor) {
+		t.Fatal("Expected the monitor to be disabled")
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors but got %d", len(errs))
+	}
+	err := errs[0]
+	if err.Name != "unstable" || err.Event != EventReactionAdd || err.Err != "oops" || err.EventContext != ctx || err.Data != data || len(err.Stack) == 0 || err.Disabled {
+		t.Errorf("Unexpected error %+v", err)
+	}
+	if !errs[1].Disabled {
+		t.Error("Expected the last error to be marked as disabled")
+	}
+}
+
+func TestMonitorPanicsConcurrent(t *testing.T) {
+	bot := &Bot{ErrorHandler: func(_ *Bot, err interface{}) {}}
+	monitor := NewMonitor("unstable", func(bot *Bot, ctx *MonitorContext) {
+		panic("oops")
+	}).SetMaxPanics(1)
+	ctx := &MonitorContext{Message: &discordgo.Message{}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			runMonitor(bot, monitor, ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			bot.monitorEnabled(monitor)
+		}()
+	}
+	wg.Wait()
+	if bot.monitorEnabled(monitor) {
+		t.Error("Expected the monitor to be disabled")
+	}
+}
@@ -0,0 +1,132 @@
+package gocto
+
+import (
+	"strings"
+	"sync"
+)
+
+// PageSource renders the pages of a paginator on demand instead of building them all before it runs.
+type PageSource interface {
+	// PageCount returns the number of pages, it can change while the paginator runs.
+	PageCount() int
+	// Page renders the page at index on em, which is created from the paginator's template.
+	Page(index int, em *Embed) (*Embed, error)
+}
+
+type funcSource struct {
+	count  func() int
+	render func(index int, em *Embed) (*Embed, error)
+}
+
+func (s *funcSource) PageCount() int {
+	return s.count()
+}
+
+func (s *funcSource) Page(index int, em *Embed) (*Embed, error) {
+	return s.render(index, em)
+}
+
+// NewFuncSource creates a page source from functions, count is called every time the page count is needed.
+func NewFuncSource(count func() int, render func(index int, em *Embed) (*Embed, error)) PageSource {
+	return &funcSource{count: count, render: render}
+}
+
+// NewListSource creates a page source that lists count items, perPage items per page, in the embed's description.
+// item formats the item at index e.g
+//
+//	NewListSource(len(users), 10, func(i int) string { return fmt.Sprintf("%d. %s", i+1, users[i].Username) })
+func NewListSource(count, perPage int, item func(index int) string) PageSource {
+	if perPage < 1 {
+		perPage = 1
+	}
+	return NewFuncSource(func() int {
+		return (count + perPage - 1) / perPage
+	}, func(index int, em *Embed) (*Embed, error) {
+		start := index * perPage
+		end := start + perPage
+		if end > count {
+			end = count
+		}
+		lines := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			lines = append(lines, item(i))
+		}
+		return em.SetDescription(strings.Join(lines, "\n")), nil
+	})
+}
+
+// CachedSource caches the pages rendered by another source, optionally prefetching the next page in the background.
+type CachedSource struct {
+	Source   PageSource
+	Prefetch bool // Wether to render the next page in the background after a page is rendered. (default: false)
+	pages    map[int]*cachedPage
+	lock     sync.Mutex
+}
+
+type cachedPage struct {
+	once  sync.Once
+	embed *Embed
+	err   error
+}
+
+// NewCachedSource wraps source in a cache.
+func NewCachedSource(source PageSource) *CachedSource {
+	return &CachedSource{
+		Source:   source,
+		Prefetch: false,
+		pages:    make(map[int]*cachedPage),
+	}
+}
+
+func (s *CachedSource) SetPrefetch(toggle bool) *CachedSource {
+	s.Prefetch = toggle
+	return s
+}
+
+func (s *CachedSource) PageCount() int {
+	return s.Source.PageCount()
+}
+
+// Page returns the cached page, rendering it once if needed. Pages that failed to render are retried next time.
+// The next page is prefetched on a copy of em so it looks like the pages rendered on demand.
+func (s *CachedSource) Page(index int, em *Embed) (*Embed, error) {
+	var next *Embed
+	if s.Prefetch && index+1 < s.PageCount() {
+		// Copied before rendering the page on it, the page isn't prefetched if it can't be copied.
+		next, _ = copyEmbed(em)
+	}
+	res, err := s.get(index, em)
+	if next != nil {
+		go s.get(index+1, next)
+	}
+	return res, err
+}
+
+func (s *CachedSource) get(index int, em *Embed) (*Embed, error) {
+	s.lock.Lock()
+	page, ok := s.pages[index]
+	if !ok {
+		page = &cachedPage{}
+		s.pages[index] = page
+	}
+	s.lock.Unlock()
+
+	page.once.Do(func() {
+		page.embed, page.err = s.Source.Page(index, em)
+	})
+	if page.err != nil {
+		s.lock.Lock()
+		if s.pages[index] == page {
+			delete(s.pages, index)
+		}
+		s.lock.Unlock()
+	}
+	return page.embed, page.err
+}
+
+// Clear empties the cache so pages are rendered again.
+func (s *CachedSource) Clear() {
+	s.lock.Lock()
+	s.pages = make(map[int]*cachedPage)
+	s.lock.Unlock()
+}
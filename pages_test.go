@@ -0,0 +1,78 @@
+package gocto
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestListSource(t *testing.T) {
+	source := NewListSource(5, 2, func(i int) string { return strconv.Itoa(i) })
+	if source.PageCount() != 3 {
+		t.Fatalf("Expected 3 pages but got %d", source.PageCount())
+	}
+	expected := []string{"0\n1", "2\n3", "4"}
+	for i, desc := range expected {
+		em, err := source.Page(i, NewEmbed())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if em.Description != desc {
+			t.Errorf("Page %d: expected %q but got %q", i, desc, em.Description)
+		}
+	}
+}
+
+func TestCachedSource(t *testing.T) {
+	renders := 0
+	fail := true
+	source := NewCachedSource(NewFuncSource(func() int { return 2 }, func(index int, em *Embed) (*Embed, error) {
+		renders++
+		if fail {
+			return nil, errors.New("failed")
+		}
+		return em.SetDescription(strconv.Itoa(index)), nil
+	}))
+
+	if _, err := source.Page(0, NewEmbed()); err == nil {
+		t.Fatal("Expected an error")
+	}
+	fail = false
+	source.Page(0, NewEmbed()) // Failed pages are retried.
+	source.Page(0, NewEmbed())
+	if renders != 2 {
+		t.Errorf("Expected 2 renders but got %d", renders)
+	}
+
+	p := NewPaginator(nil, 1, 2).SetSource(source)
+	page, err := p.Page(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Description != "1" || page.Footer == nil || page.Footer.Text != "Page 2/2 " {
+		t.Errorf("Unexpected page %+v", page)
+	}
+}
+
+func TestCachedSourcePrefetch(t *testing.T) {
+	rendered := make(chan int, 2)
+	source := NewCachedSource(NewFuncSource(func() int { return 2 }, func(index int, em *Embed) (*Embed, error) {
+		defer func() { rendered <- index }()
+		return em.SetDescription(strconv.Itoa(index)), nil
+	})).SetPrefetch(true)
+
+	source.Page(0, NewEmbed().SetColor(5))
+	for i := 0; i < 2; i++ {
+		select {
+		case <-rendered:
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for the prefetch")
+		}
+	}
+
+	page, _ := source.Page(1, NewEmbed())
+	if page.Color != 5 || page.Description != "1" {
+		t.Errorf("Expected the prefetched page to use the given template, got color %d and %q", page.Color, page.Description)
+	}
+}
@@ -1,10 +1,14 @@
 package gocto
 
 import (
-	"fmt"
+	"errors"
+	"github.com/Noctember/gocto/helpers"
 	"github.com/jonas747/discordgo"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 const (
@@ -13,42 +17,113 @@ const (
 	EmojiFirst = "⏪"
 	EmojiLast  = "⏩"
 	EmojiStop  = "⏹️"
+	EmojiJump  = "🔢"
 )
 
+// ErrPaginatorRunning is returned by Paginator.Start when the paginator is already running.
+var ErrPaginatorRunning = errors.New("paginator is already running")
+
+// ErrPaginatorEmpty is returned by Paginator.Start when the paginator has no pages.
+var ErrPaginatorEmpty = errors.New("paginator has no pages")
+
+// PaginatorEnd is what happens to the paginator's message when it stops.
+type PaginatorEnd int
+
+const (
+	EndDelete         PaginatorEnd = iota // Deletes the message.
+	EndClearReactions                     // Removes the reactions and leaves the current page.
+	EndFreeze                             // Removes the reactions and marks the current page's footer as ended.
+)
+
+// PaginatorControl is a reaction that controls a paginator.
+type PaginatorControl struct {
+	Emoji   string
+	Handler func(p *Paginator, r *discordgo.MessageReaction) // Called in its own goroutine when the emoji is reacted.
+}
+
 type Paginator struct {
-	Running   bool
-	Session   *discordgo.Session
-	ChannelID int64
-	Template  func() *Embed
-	Pages     []*discordgo.MessageEmbed
-	index     int
-	Message   *discordgo.Message
-	AuthorID  int64
-	StopChan  chan bool
-	Extra     string
-	Timeout   time.Duration
-	lock      sync.Mutex
-	delete    bool
+	Running       bool
+	Session       *discordgo.Session
+	ChannelID     int64
+	Template      func() *Embed
+	Pages         []*discordgo.MessageEmbed
+	index         int
+	Message       *discordgo.Message
+	AuthorID      int64
+	StopChan      chan bool
+	Extra         string
+	Timeout       time.Duration                    // Stops the paginator this long after it started. (default: 5 minutes)
+	IdleTimeout   time.Duration                    // Stops the paginator when nobody used it for this long, 0 to disable. (default: 0)
+	Controls      []*PaginatorControl              // The reactions that control the paginator. (default: first, previous, stop, next, last)
+	AllowedUsers  []int64                          // Users allowed to control the paginator besides AuthorID.
+	AllowAnyone   bool                             // Wether anyone can control the paginator. (default: false)
+	StopAction    PaginatorEnd                     // What happens when the paginator is stopped. (default: EndDelete)
+	TimeoutAction PaginatorEnd                     // What happens when the paginator times out. (default: EndClearReactions)
+	OnPageChange  func(p *Paginator, from, to int) // Called after the page changed.
+	OnStop        func(p *Paginator)               // Called after the paginator was stopped.
+	OnTimeout     func(p *Paginator)               // Called after the paginator timed out.
+	timedOut      bool
+	lock          sync.Mutex
+	delete        bool
+	Localize      func(key string, args ...interface{}) string // Localizes the paginator's texts. (default: English)
+	Router        *ReactionRouter                              // Routes the reactions to the paginator, nil to use its own. (default: bot.Reactions for NewPaginatorForContext)
+	Source        PageSource                                   // Renders the pages on demand instead of using Pages. (default: nil)
+	Answers       *AnswerRouter                                // Routes the answers to the jump prompt, nil to use its own. (default: bot.Answers for NewPaginatorForContext)
+
+	ctx *CommandContext // The command the paginator responds to, its message is tracked with the command's responses.
 }
 
 func NewPaginator(session *discordgo.Session, channel, author int64) *Paginator {
 	return &Paginator{
-		Session:   session,
-		ChannelID: channel,
-		Running:   false,
-		index:     0,
-		Message:   nil,
-		AuthorID:  author,
-		StopChan:  make(chan bool),
-		Timeout:   time.Minute * 5,
-		Extra:     "",
-		Template:  func() *Embed { return NewEmbed() },
-		delete:    false,
+		Session:       session,
+		ChannelID:     channel,
+		Running:       false,
+		index:         0,
+		Message:       nil,
+		AuthorID:      author,
+		StopChan:      make(chan bool, 1),
+		StopAction:    EndDelete,
+		TimeoutAction: EndClearReactions,
+		Timeout:       time.Minute * 5,
+		IdleTimeout:   0,
+		Controls:      DefaultPaginatorControls(),
+		Extra:         "",
+		Template:      func() *Embed { return NewEmbed() },
+		delete:        false,
+		Localize: func(key string, args ...interface{}) string {
+			return English.Get(key, args...)
+		},
 	}
 }
 
+// NewPaginatorForContext creates a paginator for the context's channel and author, localized in the context's locale.
 func NewPaginatorForContext(ctx *CommandContext) *Paginator {
-	return NewPaginator(ctx.Session, ctx.Channel.ID, ctx.Author.ID)
+	p := NewPaginator(ctx.Session, ctx.Channel.ID, ctx.Author.ID)
+	p.Localize = ctx.Localize
+	p.Router = ctx.Bot.Reactions
+	p.Answers = ctx.Bot.Answers
+	p.ctx = ctx
+	return p
+}
+
+// NewPaginatorFromText creates a paginator for the context with the text split into pages on line boundaries,
+// code blocks cut by a page boundary are closed and reopened on the next page.
+// opts.MaxLength defaults to EmbedLimitDescription.
+func NewPaginatorFromText(ctx *CommandContext, text string, opts helpers.SplitOptions) *Paginator {
+	return NewPaginatorFromLines(ctx, strings.Split(text, "\n"), opts)
+}
+
+// NewPaginatorFromLines creates a paginator for the context with the lines joined into pages,
+// see NewPaginatorFromText.
+func NewPaginatorFromLines(ctx *CommandContext, lines []string, opts helpers.SplitOptions) *Paginator {
+	if opts.MaxLength <= 0 || opts.MaxLength > EmbedLimitDescription {
+		opts.MaxLength = EmbedLimitDescription
+	}
+	p := NewPaginatorForContext(ctx)
+	for _, page := range helpers.SplitLines(lines, opts) {
+		p.AddPageString(page)
+	}
+	return p
 }
 
 func (p *Paginator) SetTemplate(em func() *Embed) {
@@ -75,20 +150,212 @@ func (p *Paginator) AddPageString(str string) {
 	})
 }
 
+// DefaultPaginatorControls returns the default controls: first, previous, stop, next and last page.
+func DefaultPaginatorControls() []*PaginatorControl {
+	return []*PaginatorControl{
+		{EmojiFirst, func(p *Paginator, _ *discordgo.MessageReaction) { p.Goto(0) }},
+		{EmojiLeft, func(p *Paginator, _ *discordgo.MessageReaction) { p.PreviousPage() }},
+		{EmojiStop, func(p *Paginator, _ *discordgo.MessageReaction) { p.Stop() }},
+		{EmojiRight, func(p *Paginator, _ *discordgo.MessageReaction) { p.NextPage() }},
+		{EmojiLast, func(p *Paginator, _ *discordgo.MessageReaction) { p.Goto(p.PageCount() - 1) }},
+	}
+}
+
+// AddControl adds a reaction control, replacing the one with the same emoji.
+func (p *Paginator) AddControl(emoji string, handler func(p *Paginator, r *discordgo.MessageReaction)) *Paginator {
+	p.RemoveControl(emoji)
+	p.Controls = append(p.Controls, &PaginatorControl{Emoji: emoji, Handler: handler})
+	return p
+}
+
+func (p *Paginator) RemoveControl(emoji string) *Paginator {
+	for i, control := range p.Controls {
+		if control.Emoji == emoji {
+			p.Controls = append(p.Controls[:i], p.Controls[i+1:]...)
+			break
+		}
+	}
+	return p
+}
+
+// AddJump adds a control that asks the user to type the number of the page to go to.
+func (p *Paginator) AddJump() *Paginator {
+	return p.AddControl(EmojiJump, func(p *Paginator, r *discordgo.MessageReaction) { p.jump(r.UserID) })
+}
+
+// SetIdleTimeout stops the paginator when nobody used it for d, the timeout resets on every interaction.
+func (p *Paginator) SetIdleTimeout(d time.Duration) *Paginator {
+	p.IdleTimeout = d
+	return p
+}
+
+// AllowUsers allows the users to control the paginator besides the author.
+func (p *Paginator) AllowUsers(ids ...int64) *Paginator {
+	p.AllowedUsers = append(p.AllowedUsers, ids...)
+	return p
+}
+
+// SetAllowAnyone sets wether anyone can control the paginator.
+func (p *Paginator) SetAllowAnyone(toggle bool) *Paginator {
+	p.AllowAnyone = toggle
+	return p
+}
+
+// CanControl returns true if the user is allowed to control the paginator.
+func (p *Paginator) CanControl(userID int64) bool {
+	if p.AllowAnyone || p.AuthorID == 0 || userID == p.AuthorID {
+		return true
+	}
+	return containsID(p.AllowedUsers, userID)
+}
+
+func (p *Paginator) control(emoji string) *PaginatorControl {
+	for _, control := range p.Controls {
+		if control.Emoji == emoji {
+			return control
+		}
+	}
+	return nil
+}
+
 func (p *Paginator) addReactions() {
 	if p.Message == nil {
 		return
 	}
-	p.Session.MessageReactionAdd(p.ChannelID, p.Message.ID, EmojiFirst)
-	p.Session.MessageReactionAdd(p.ChannelID, p.Message.ID, EmojiLeft)
-	p.Session.MessageReactionAdd(p.ChannelID, p.Message.ID, EmojiStop)
-	p.Session.MessageReactionAdd(p.ChannelID, p.Message.ID, EmojiRight)
-	p.Session.MessageReactionAdd(p.ChannelID, p.Message.ID, EmojiLast)
+	for _, control := range p.Controls {
+		p.Session.MessageReactionAdd(p.ChannelID, p.Message.ID, control.Emoji)
+	}
+}
+
+// removeReactions removes all reactions or only the bot's own if it lacks the permission to.
+func (p *Paginator) removeReactions() {
+	if err := p.Session.MessageReactionsRemoveAll(p.ChannelID, p.Message.ID); err != nil {
+		for _, control := range p.Controls {
+			p.Session.MessageReactionRemoveMe(p.ChannelID, p.Message.ID, control.Emoji)
+		}
+	}
+}
+
+// jump asks the user for a page number and goes to it.
+// Valid answers are deleted and the commands don't run on them, anything else ends the prompt.
+func (p *Paginator) jump(userID int64) {
+	prompt, err := p.Session.ChannelMessageSend(p.ChannelID, p.Localize("PAGINATOR_JUMP", LocaleArgs{"total": p.PageCount()}))
+	if err != nil {
+		return
+	}
+	defer p.Session.ChannelMessageDelete(p.ChannelID, prompt.ID)
+
+	router := p.Answers
+	if router == nil {
+		// Not attached to a bot, route the answers of this paginator alone.
+		router = NewAnswerRouter()
+		defer router.AddHandlers(p.Session)()
+	}
+
+	// The page is 0 for invalid answers.
+	type answer struct {
+		message *discordgo.Message
+		page    int
+	}
+	answers := make(chan answer, 1)
+	defer router.Register(p.ChannelID, userID, func(m *discordgo.Message) bool {
+		page, err := strconv.Atoi(strings.TrimSpace(m.Content))
+		if err != nil || page < 1 || page > p.PageCount() {
+			page = 0
+		}
+		select {
+		case answers <- answer{m, page}:
+			return page != 0
+		default:
+			// Already answered.
+			return false
+		}
+	})()
+
+	select {
+	case a := <-answers:
+		if a.page != 0 {
+			p.Session.ChannelMessageDelete(a.message.ChannelID, a.message.ID)
+			p.Goto(a.page - 1)
+		}
+	case <-time.After(time.Second * 30):
+	}
 }
 
 // Stops the paginator by sending the signal to the Stop Channel.
+// It doesn't block and does nothing if the paginator is already stopping.
 func (p *Paginator) Stop() {
-	p.StopChan <- true
+	select {
+	case p.StopChan <- true:
+	default:
+	}
+}
+
+// SetEndAction sets what happens to the message when the paginator is stopped or times out.
+func (p *Paginator) SetEndAction(action PaginatorEnd) *Paginator {
+	p.StopAction = action
+	p.TimeoutAction = action
+	return p
+}
+
+// end applies the end action to the message.
+func (p *Paginator) end(action PaginatorEnd) {
+	switch action {
+	case EndDelete:
+		p.Session.ChannelMessageDelete(p.ChannelID, p.Message.ID)
+	case EndClearReactions:
+		p.removeReactions()
+	case EndFreeze:
+		p.removeReactions()
+		index := p.GetIndex()
+		page, err := p.Page(index)
+		if err != nil {
+			return
+		}
+		frozen := *page
+		frozen.Footer = &discordgo.MessageEmbedFooter{
+			Text: p.Localize("PAGINATOR_FOOTER_ENDED", LocaleArgs{"page": index + 1, "total": p.PageCount(), "extra": p.Extra}),
+		}
+		p.Session.ChannelMessageEditEmbed(p.ChannelID, p.Message.ID, &frozen)
+	}
+}
+
+// PaginatorHandle is returned by RunAsync to follow a running paginator.
+type PaginatorHandle struct {
+	Paginator *Paginator
+	done      chan struct{}
+}
+
+// Done returns a channel that is closed when the paginator stopped.
+func (h *PaginatorHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait blocks until the paginator stopped and returns the index of the last page viewed.
+func (h *PaginatorHandle) Wait() int {
+	<-h.done
+	return h.Paginator.GetIndex()
+}
+
+// Index returns the index of the current page, or the last page viewed once the paginator stopped.
+func (h *PaginatorHandle) Index() int {
+	return h.Paginator.GetIndex()
+}
+
+// TimedOut returns true if the paginator stopped because it timed out rather than being stopped.
+func (h *PaginatorHandle) TimedOut() bool {
+	<-h.done
+	return h.Paginator.timedOut
+}
+
+// RunAsync runs the paginator in a new goroutine.
+func (p *Paginator) RunAsync() *PaginatorHandle {
+	h := &PaginatorHandle{Paginator: p, done: make(chan struct{})}
+	go func() {
+		defer close(h.done)
+		p.Run()
+	}()
+	return h
 }
 
 func (p *Paginator) SetExtra(extra string) {
@@ -99,7 +366,7 @@ func (p *Paginator) SetExtra(extra string) {
 // returns 0 to go back to first page if we are on last page already.
 func (p *Paginator) getNextIndex() int {
 	index := p.GetIndex()
-	if index >= len(p.Pages)-1 {
+	if index >= p.PageCount()-1 {
 		return 0
 	}
 	return index + 1
@@ -110,7 +377,7 @@ func (p *Paginator) getNextIndex() int {
 func (p *Paginator) getPreviousIndex() int {
 	index := p.GetIndex()
 	if index == 0 {
-		return len(p.Pages) - 1
+		return p.PageCount() - 1
 	}
 	return index - 1
 }
@@ -119,20 +386,81 @@ func (p *Paginator) getPreviousIndex() int {
 // Called by Run to initialize.
 func (p *Paginator) SetFooter() {
 	for index, embed := range p.Pages {
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Page %d/%d %s", index+1, len(p.Pages), p.Extra),
+		embed.Footer = p.footer(index)
+	}
+}
+
+func (p *Paginator) footer(index int) *discordgo.MessageEmbedFooter {
+	return &discordgo.MessageEmbedFooter{Text: p.footerText(index, p.PageCount())}
+}
+
+func (p *Paginator) footerText(index, total int) string {
+	return p.Localize("PAGINATOR_FOOTER", LocaleArgs{"page": index + 1, "total": total, "extra": p.Extra})
+}
+
+// AddSplitPages splits the embeds with Embed.SplitReserved and adds the parts as pages,
+// each part keeps room for the paginator's footer. Set the paginator's Extra before adding them.
+func (p *Paginator) AddSplitPages(embeds ...*Embed) *Paginator {
+	// The footer grows with the number of pages, split again until the room reserved is enough for the last page's.
+	total := len(p.Pages) + len(embeds)
+	for {
+		reserved := utf8.RuneCountInString(p.footerText(total-1, total))
+		pages := p.Pages
+		for _, em := range embeds {
+			pages = append(pages, em.SplitReserved(reserved)...)
+		}
+		if len(pages) <= total {
+			p.Pages = pages
+			return p
 		}
+		total = len(pages)
 	}
 }
 
+// SetSource sets the source that renders the pages on demand, Pages is ignored when a source is set.
+func (p *Paginator) SetSource(source PageSource) *Paginator {
+	p.Source = source
+	return p
+}
+
+// PageCount returns the number of pages.
+func (p *Paginator) PageCount() int {
+	if p.Source != nil {
+		return p.Source.PageCount()
+	}
+	return len(p.Pages)
+}
+
+// Page returns the page at index with its footer, rendering it if the paginator has a source.
+func (p *Paginator) Page(index int) (*discordgo.MessageEmbed, error) {
+	if p.Source == nil {
+		return p.Pages[index], nil
+	}
+	em, err := p.Source.Page(index, p.Template())
+	if err != nil {
+		return nil, err
+	}
+	// Copy it as sources may cache their pages.
+	page := *em.Build()
+	page.Footer = p.footer(index)
+	return &page, nil
+}
+
 // Switches pages, index is assumed to be a valid index. (can panic if it's not)
-// Edits the current message to the given page and updates the index.
+// Edits the current message to the given page and updates the index, nothing happens if the page fails to render.
 func (p *Paginator) Goto(index int) {
-	page := p.Pages[index]
+	page, err := p.Page(index)
+	if err != nil {
+		return
+	}
 	p.Session.ChannelMessageEditEmbed(p.ChannelID, p.Message.ID, page)
 	p.lock.Lock()
+	from := p.index
 	p.index = index
 	p.lock.Unlock()
+	if p.OnPageChange != nil && from != index {
+		p.OnPageChange(p, from, index)
+	}
 }
 
 // Switches to next page, this is safer than raw Goto as it compares indices
@@ -147,80 +475,163 @@ func (p *Paginator) PreviousPage() {
 	p.Goto(p.getPreviousIndex())
 }
 
-func (p *Paginator) nextReaction() chan *discordgo.MessageReactionAdd {
-	channel := make(chan *discordgo.MessageReactionAdd)
-	p.Session.AddHandlerOnce(func(_ *discordgo.Session, r *discordgo.MessageReactionAdd) {
-		channel <- r
-	})
-	return channel
+func (p *Paginator) timeout() {
+	p.timedOut = true
+	p.end(p.TimeoutAction)
+	if p.OnTimeout != nil {
+		p.OnTimeout(p)
+	}
 }
 
+// Run sends the paginator and blocks until it is stopped or times out, see RunAsync to run it in the background.
 func (p *Paginator) Run() {
-	if p.Running {
+	msg, err := p.send()
+	if err != nil {
 		return
 	}
-	if len(p.Pages) == 0 {
-		return
+	p.listen(msg)
+}
+
+// Start sends the first page and returns its message, then runs the rest of the paginator in a new goroutine like RunAsync.
+// If the first page can't be sent the error is returned and the handle is already done.
+func (p *Paginator) Start() (*discordgo.Message, *PaginatorHandle, error) {
+	h := &PaginatorHandle{Paginator: p, done: make(chan struct{})}
+	msg, err := p.send()
+	if err != nil {
+		close(h.done)
+		return nil, h, err
+	}
+	go func() {
+		defer close(h.done)
+		p.listen(msg)
+	}()
+	return msg, h, nil
+}
+
+// send sends the first page.
+func (p *Paginator) send() (*discordgo.Message, error) {
+	if p.Running {
+		return nil, ErrPaginatorRunning
+	}
+	if p.PageCount() == 0 {
+		return nil, ErrPaginatorEmpty
+	}
+	if p.Source == nil {
+		p.SetFooter()
 	}
-	p.SetFooter()
-	msg, err := p.Session.ChannelMessageSendEmbed(p.ChannelID, p.Pages[0])
+	first, err := p.Page(0)
 	if err != nil {
-		return
+		return nil, err
+	}
+	var msg *discordgo.Message
+	if p.ctx != nil {
+		// Tracked as an extra response so an edit re-running the command deletes it, which stops the paginator.
+		msg, err = p.ctx.Send(MessageOptions{Embed: first, NoEdit: true})
+	} else {
+		msg, err = p.Session.ChannelMessageSendEmbed(p.ChannelID, first)
+	}
+	if err != nil {
+		return nil, err
 	}
 	p.Message = msg
-	if len(p.Pages) != 1 {
+	return msg, nil
+}
+
+// listen handles the reactions on the paginator's message until it stops.
+func (p *Paginator) listen(msg *discordgo.Message) {
+	router := p.Router
+	if router == nil {
+		// Not attached to a bot, route the reactions of this paginator alone.
+		router = NewReactionRouter()
+		defer router.AddHandlers(p.Session)()
+	}
+
+	reactions := make(chan *discordgo.MessageReaction)
+	done := make(chan struct{})
+	unregister := router.Register(msg.ID, func(r *discordgo.MessageReaction, added bool) {
+		if !added {
+			return
+		}
+		select {
+		case reactions <- r:
+		case <-done:
+		}
+	})
+	deleted := make(chan struct{})
+	removeDelete := router.OnDelete(msg.ID, func() { close(deleted) })
+
+	if p.PageCount() != 1 {
 		p.addReactions()
 	}
 
+	// Ignore Stop calls from before the paginator ran.
+	select {
+	case <-p.StopChan:
+	default:
+	}
+	p.timedOut = false
 	p.Running = true
-	start := time.Now()
-	var r *discordgo.MessageReaction
+	timeout := time.After(p.Timeout)
+	var idle <-chan time.Time
+	var idleTimer *time.Timer
+	if p.IdleTimeout > 0 {
+		idleTimer = time.NewTimer(p.IdleTimeout)
+		idle = idleTimer.C
+	}
 
 	defer func() {
+		unregister()
+		removeDelete()
+		close(done)
+		if idleTimer != nil {
+			idleTimer.Stop()
+		}
 		p.Running = false
 	}()
 
 	for {
+		var r *discordgo.MessageReaction
 		select {
-		case e := <-p.nextReaction():
-			r = e.MessageReaction
-		case <-time.After(start.Add(p.Timeout).Sub(time.Now())):
-			p.Session.MessageReactionsRemoveAll(p.ChannelID, p.Message.ID)
+		case r = <-reactions:
+		case <-timeout:
+			p.timeout()
+			return
+		case <-idle:
+			p.timeout()
 			return
 		case <-p.StopChan:
-			p.Session.ChannelMessageDelete(p.ChannelID, p.Message.ID)
+			p.end(p.StopAction)
+			if p.OnStop != nil {
+				p.OnStop(p)
+			}
+			return
+		case <-deleted:
+			// The message is gone, there is nothing to end.
+			if p.OnStop != nil {
+				p.OnStop(p)
+			}
 			return
 		}
 
-		if r.MessageID != p.Message.ID {
+		if p.Session.State.User != nil && r.UserID == p.Session.State.User.ID {
+			continue
+		}
+		if !p.CanControl(r.UserID) {
 			continue
 		}
-		if p.AuthorID != 0 && r.UserID != p.AuthorID {
+		control := p.control(r.Emoji.Name)
+		if control == nil {
 			continue
 		}
 
-		go func() {
-			switch r.Emoji.Name {
-			case EmojiStop:
-				p.Stop()
-				err := p.Session.MessageReactionsRemoveAll(p.ChannelID, p.Message.ID)
-				if err != nil {
-					p.Session.MessageReactionRemoveMe(p.ChannelID, p.Message.ID, EmojiFirst)
-					p.Session.MessageReactionRemoveMe(p.ChannelID, p.Message.ID, EmojiLeft)
-					p.Session.MessageReactionRemoveMe(p.ChannelID, p.Message.ID, EmojiStop)
-					p.Session.MessageReactionRemoveMe(p.ChannelID, p.Message.ID, EmojiRight)
-					p.Session.MessageReactionRemoveMe(p.ChannelID, p.Message.ID, EmojiLast)
-				}
-			case EmojiRight:
-				p.NextPage()
-			case EmojiLeft:
-				p.PreviousPage()
-			case EmojiFirst:
-				p.Goto(0)
-			case EmojiLast:
-				p.Goto(len(p.Pages) - 1)
+		if idleTimer != nil {
+			if !idleTimer.Stop() {
+				<-idleTimer.C
 			}
-		}()
+			idleTimer.Reset(p.IdleTimeout)
+		}
+
+		go control.Handler(p, r)
 		go func() {
 			time.Sleep(time.Millisecond * 250)
 			p.Session.MessageReactionRemove(r.ChannelID, r.MessageID, r.Emoji.Name, r.UserID)
@@ -0,0 +1,80 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"strings"
+	"testing"
+)
+
+func TestPaginatorControls(t *testing.T) {
+	p := NewPaginator(nil, 1, 2)
+	if !p.CanControl(2) || p.CanControl(3) {
+		t.Error("Expected only the author to control the paginator")
+	}
+	p.AllowUsers(3)
+	if !p.CanControl(3) || p.CanControl(4) {
+		t.Error("Expected allowed users to control the paginator")
+	}
+	p.SetAllowAnyone(true)
+	if !p.CanControl(4) {
+		t.Error("Expected anyone to control the paginator")
+	}
+
+	noop := func(p *Paginator, r *discordgo.MessageReaction) {}
+	p.AddJump().RemoveControl(EmojiFirst).AddControl(EmojiLast, noop)
+	emojis := ""
+	for _, control := range p.Controls {
+		emojis += control.Emoji
+	}
+	if expected := EmojiLeft + EmojiStop + EmojiRight + EmojiJump + EmojiLast; emojis != expected {
+		t.Errorf("Expected controls %q but got %q", expected, emojis)
+	}
+}
+
+func TestPaginatorRunAsync(t *testing.T) {
+	p := NewPaginator(nil, 1, 2)
+	p.Stop()
+	p.Stop() // Doesn't block when nothing is running.
+
+	// Without pages Run returns immediately.
+	h := p.RunAsync()
+	if index := h.Wait(); index != 0 {
+		t.Errorf("Expected index 0 but got %d", index)
+	}
+	if h.TimedOut() {
+		t.Error("Expected the paginator to not time out")
+	}
+}
+
+func TestPaginatorStart(t *testing.T) {
+	p := NewPaginator(nil, 1, 2)
+	msg, h, err := p.Start()
+	if msg != nil || err != ErrPaginatorEmpty {
+		t.Errorf("Expected ErrPaginatorEmpty without pages but got %v, %v", msg, err)
+	}
+	select {
+	case <-h.Done():
+	default:
+		t.Error("Expected the handle to be done when the first page wasn't sent")
+	}
+}
+
+func TestPaginatorAddSplitPages(t *testing.T) {
+	p := NewPaginator(nil, 1, 2)
+	p.SetExtra(strings.Repeat("e", 1000))
+	em := NewEmbed()
+	for i := 0; i < 20; i++ {
+		em.AddField("name", strings.Repeat("v", 1000))
+	}
+	p.AddSplitPages(em)
+	if len(p.Pages) < 2 {
+		t.Fatalf("Expected the embed to be split, got %d pages", len(p.Pages))
+	}
+
+	p.SetFooter()
+	for i, page := range p.Pages {
+		if length := embedLength(page); length > EmbedLimit {
+			t.Errorf("Page %d with its footer is %d long, over the limit", i, length)
+		}
+	}
+}
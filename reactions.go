@@ -0,0 +1,139 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"sync"
+)
+
+// ReactionHandler is called for reactions added to or removed from a message registered with a ReactionRouter.
+// It runs in the session's event handler so it should not block.
+type ReactionHandler func(r *discordgo.MessageReaction, added bool)
+
+// ReactionRouter routes reaction events to the handlers registered for their message,
+// paginators, menus and reaction collectors register with it instead of adding a session handler each.
+// It also tells them when their message is deleted, see OnDelete.
+type ReactionRouter struct {
+	handlers map[int64]map[uint64]ReactionHandler // message ID -> handler ID -> handler
+	deletes  map[int64]map[uint64]func()          // message ID -> handler ID -> delete handler
+	nextID   uint64
+	lock     sync.RWMutex
+}
+
+func NewReactionRouter() *ReactionRouter {
+	return &ReactionRouter{
+		handlers: make(map[int64]map[uint64]ReactionHandler),
+		deletes:  make(map[int64]map[uint64]func()),
+	}
+}
+
+// Register adds a handler for the reactions on the message.
+// Returns a function that removes the handler, it is safe to call more than once.
+func (router *ReactionRouter) Register(messageID int64, handler ReactionHandler) func() {
+	router.lock.Lock()
+	router.nextID++
+	id := router.nextID
+	if router.handlers[messageID] == nil {
+		router.handlers[messageID] = make(map[uint64]ReactionHandler)
+	}
+	router.handlers[messageID][id] = handler
+	router.lock.Unlock()
+
+	return func() {
+		router.lock.Lock()
+		defer router.lock.Unlock()
+		handlers := router.handlers[messageID]
+		delete(handlers, id)
+		if len(handlers) == 0 {
+			delete(router.handlers, messageID)
+		}
+	}
+}
+
+// OnDelete adds a function called once when the message is deleted, e.g by the command's ResponseTTL or an edit re-running it.
+// It runs in the session's event handler so it should not block.
+// Returns a function that removes it, it is safe to call more than once.
+func (router *ReactionRouter) OnDelete(messageID int64, handler func()) func() {
+	router.lock.Lock()
+	router.nextID++
+	id := router.nextID
+	if router.deletes[messageID] == nil {
+		router.deletes[messageID] = make(map[uint64]func())
+	}
+	router.deletes[messageID][id] = handler
+	router.lock.Unlock()
+
+	return func() {
+		router.lock.Lock()
+		defer router.lock.Unlock()
+		handlers := router.deletes[messageID]
+		delete(handlers, id)
+		if len(handlers) == 0 {
+			delete(router.deletes, messageID)
+		}
+	}
+}
+
+// Deleted calls and removes the delete handlers of the message.
+func (router *ReactionRouter) Deleted(messageID int64) {
+	router.lock.Lock()
+	handlers := router.deletes[messageID]
+	delete(router.deletes, messageID)
+	router.lock.Unlock()
+
+	for _, handler := range handlers {
+		handler()
+	}
+}
+
+// Unregister removes every handler of the message.
+func (router *ReactionRouter) Unregister(messageID int64) {
+	router.lock.Lock()
+	delete(router.handlers, messageID)
+	router.lock.Unlock()
+}
+
+// Len returns the number of messages with handlers.
+func (router *ReactionRouter) Len() int {
+	router.lock.RLock()
+	defer router.lock.RUnlock()
+	return len(router.handlers)
+}
+
+// Dispatch calls the handlers registered for the reaction's message.
+func (router *ReactionRouter) Dispatch(r *discordgo.MessageReaction, added bool) {
+	router.lock.RLock()
+	handlers := make([]ReactionHandler, 0, len(router.handlers[r.MessageID]))
+	for _, handler := range router.handlers[r.MessageID] {
+		handlers = append(handlers, handler)
+	}
+	router.lock.RUnlock()
+
+	for _, handler := range handlers {
+		handler(r, added)
+	}
+}
+
+// AddHandlers adds the session handlers that dispatch reactions and deletions to the router.
+// Returns a function that removes them.
+func (router *ReactionRouter) AddHandlers(s *discordgo.Session) func() {
+	removeAdd := s.AddHandler(func(_ *discordgo.Session, r *discordgo.MessageReactionAdd) {
+		router.Dispatch(r.MessageReaction, true)
+	})
+	removeRemove := s.AddHandler(func(_ *discordgo.Session, r *discordgo.MessageReactionRemove) {
+		router.Dispatch(r.MessageReaction, false)
+	})
+	removeDelete := s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDelete) {
+		router.Deleted(m.ID)
+	})
+	removeDeleteBulk := s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDeleteBulk) {
+		for _, id := range m.Messages {
+			router.Deleted(id)
+		}
+	})
+	return func() {
+		removeAdd()
+		removeRemove()
+		removeDelete()
+		removeDeleteBulk()
+	}
+}
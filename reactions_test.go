@@ -0,0 +1,56 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"testing"
+)
+
+func TestReactionRouter(t *testing.T) {
+	router := NewReactionRouter()
+	calls := 0
+	unregister := router.Register(1, func(r *discordgo.MessageReaction, added bool) {
+		calls++
+	})
+	router.Register(2, func(r *discordgo.MessageReaction, added bool) {
+		t.Error("Handler of another message was called")
+	})
+
+	router.Dispatch(&discordgo.MessageReaction{MessageID: 1}, true)
+	router.Dispatch(&discordgo.MessageReaction{MessageID: 3}, true)
+	if calls != 1 {
+		t.Errorf("Expected 1 call but got %d", calls)
+	}
+
+	unregister()
+	unregister()
+	router.Dispatch(&discordgo.MessageReaction{MessageID: 1}, true)
+	if calls != 1 {
+		t.Errorf("Expected the handler to be unregistered")
+	}
+	if router.Len() != 1 {
+		t.Errorf("Expected 1 registered message but got %d", router.Len())
+	}
+
+	router.Unregister(2)
+	if router.Len() != 0 {
+		t.Errorf("Expected no registered messages but got %d", router.Len())
+	}
+}
+
+func TestReactionRouterOnDelete(t *testing.T) {
+	router := NewReactionRouter()
+	calls := 0
+	router.OnDelete(1, func() { calls++ })
+	remove := router.OnDelete(2, func() {
+		t.Error("Removed delete handler was called")
+	})
+	remove()
+	remove()
+
+	router.Deleted(1)
+	router.Deleted(1)
+	router.Deleted(2)
+	if calls != 1 {
+		t.Errorf("Expected the delete handler to be called once but got %d calls", calls)
+	}
+}
@@ -0,0 +1,193 @@
+package gocto
+
+import (
+	"github.com/jonas747/discordgo"
+	"sync"
+	"time"
+)
+
+// Responses are the messages the bot sent in response to a command message.
+type Responses struct {
+	ChannelID int64
+	Primary   int64   // The response edited when the command is edited, 0 if there is none.
+	Extra     []int64 // The other responses e.g the rest of a split reply, deleted when an edit re-runs the command.
+}
+
+// IDs returns the IDs of every response, starting with the primary one.
+func (r *Responses) IDs() []int64 {
+	ids := make([]int64, 0, len(r.Extra)+1)
+	if r.Primary != 0 {
+		ids = append(ids, r.Primary)
+	}
+	return append(ids, r.Extra...)
+}
+
+func (r *Responses) copy() *Responses {
+	return &Responses{
+		ChannelID: r.ChannelID,
+		Primary:   r.Primary,
+		Extra:     append([]int64(nil), r.Extra...),
+	}
+}
+
+// ResponseStore stores the responses of command messages by the ID of the command message,
+// implement it over a database to keep editing and deleting responses across restarts.
+type ResponseStore interface {
+	Get(messageID int64) (*Responses, error) // Returns nil if the message has no responses.
+	Set(messageID int64, responses *Responses) error
+	Delete(messageID int64) error
+	Prune(before time.Time) error // Deletes the responses of command messages sent before the time, see SnowflakeTime.
+}
+
+// MemoryResponseStore keeps the responses in memory, they are forgotten on restart.
+type MemoryResponseStore struct {
+	lock      sync.RWMutex
+	responses map[int64]*Responses
+}
+
+func NewMemoryResponseStore() *MemoryResponseStore {
+	return &MemoryResponseStore{responses: make(map[int64]*Responses)}
+}
+
+func (s *MemoryResponseStore) Get(messageID int64) (*Responses, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	responses, ok := s.responses[messageID]
+	if !ok {
+		return nil, nil
+	}
+	return responses.copy(), nil
+}
+
+func (s *MemoryResponseStore) Set(messageID int64, responses *Responses) error {
+	s.lock.Lock()
+	s.responses[messageID] = responses.copy()
+	s.lock.Unlock()
+	return nil
+}
+
+func (s *MemoryResponseStore) Delete(messageID int64) error {
+	s.lock.Lock()
+	delete(s.responses, messageID)
+	s.lock.Unlock()
+	return nil
+}
+
+// Len returns how many command messages have responses stored.
+func (s *MemoryResponseStore) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.responses)
+}
+
+func (s *MemoryResponseStore) Prune(before time.Time) error {
+	s.lock.Lock()
+	for id := range s.responses {
+		if SnowflakeTime(id).Before(before) {
+			delete(s.responses, id)
+		}
+	}
+	s.lock.Unlock()
+	return nil
+}
+
+// Clear forgets every response.
+func (s *MemoryResponseStore) Clear() {
+	s.lock.Lock()
+	s.responses = make(map[int64]*Responses)
+	s.lock.Unlock()
+}
+
+// SetResponseStore sets where the responses of commands are stored.
+func (bot *Bot) SetResponseStore(store ResponseStore) *Bot {
+	bot.Responses = store
+	return bot
+}
+
+// SetResponseLifetime sets how long the responses of a command are tracked.
+func (bot *Bot) SetResponseLifetime(lifetime time.Duration) *Bot {
+	bot.ResponseLifetime = lifetime
+	return bot
+}
+
+// SetDeleteResponses sets wether the responses of a command are deleted when its message is deleted.
+func (bot *Bot) SetDeleteResponses(toggle bool) *Bot {
+	bot.DeleteResponses = toggle
+	return bot
+}
+
+// clearResponses clears the response store if it is kept in memory.
+func (bot *Bot) clearResponses() {
+	if store, ok := bot.Responses.(*MemoryResponseStore); ok {
+		store.Clear()
+	}
+}
+
+// pruneResponses forgets the responses of commands older than ResponseLifetime.
+func (bot *Bot) pruneResponses() {
+	if err := bot.Responses.Prune(time.Now().Add(-bot.ResponseLifetime)); err != nil {
+		bot.ErrorHandler(bot, err)
+	}
+}
+
+// forgetResponses deletes the responses of a deleted command message if DeleteResponses is set.
+// Without it the store is left alone and its entries are pruned, so there is no lookup for every deleted message.
+func (bot *Bot) forgetResponses(messageID int64) {
+	if !bot.DeleteResponses || time.Since(SnowflakeTime(messageID)) > bot.ResponseLifetime {
+		return
+	}
+	responses, err := bot.Responses.Get(messageID)
+	if err != nil {
+		bot.ErrorHandler(bot, err)
+		return
+	}
+	if responses == nil {
+		return
+	}
+	for _, id := range responses.IDs() {
+		bot.Session.ChannelMessageDelete(responses.ChannelID, id)
+	}
+	if err := bot.Responses.Delete(messageID); err != nil {
+		bot.ErrorHandler(bot, err)
+	}
+}
+
+func addResponseListeners(bot *Bot, s *discordgo.Session) {
+	s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDelete) {
+		bot.forgetResponses(m.ID)
+	})
+	s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDeleteBulk) {
+		for _, id := range m.Messages {
+			bot.forgetResponses(id)
+		}
+	})
+}
+
+// responses returns the stored responses of the command message.
+// The first time it is called in a run of an editable command the extra responses of the previous run are deleted.
+// The caller must hold sendLock.
+func (ctx *CommandContext) responses() *Responses {
+	responses, err := ctx.Bot.Responses.Get(ctx.Message.ID)
+	if err != nil {
+		ctx.Bot.ErrorHandler(ctx.Bot, err)
+	}
+	if responses == nil {
+		responses = &Responses{}
+	}
+	responses.ChannelID = ctx.Channel.ID
+
+	if !ctx.responded && ctx.Command.Editable && len(responses.Extra) > 0 {
+		for _, id := range responses.Extra {
+			ctx.Session.ChannelMessageDelete(ctx.Channel.ID, id)
+		}
+		responses.Extra = nil
+	}
+	ctx.responded = true
+	return responses
+}
+
+func (ctx *CommandContext) saveResponses(responses *Responses) {
+	if err := ctx.Bot.Responses.Set(ctx.Message.ID, responses); err != nil {
+		ctx.Bot.ErrorHandler(ctx.Bot, err)
+	}
+}
@@ -0,0 +1,60 @@
+package gocto
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMemoryResponseStore(t *testing.T) {
+	store := NewMemoryResponseStore()
+	if r, err := store.Get(1); r != nil || err != nil {
+		t.Fatalf("Expected no responses, got %v, %v", r, err)
+	}
+
+	responses := &Responses{ChannelID: 2, Primary: 3, Extra: []int64{4}}
+	store.Set(1, responses)
+	responses.Extra[0] = 5
+
+	got, _ := store.Get(1)
+	if !reflect.DeepEqual(got.IDs(), []int64{3, 4}) {
+		t.Errorf("Expected the stored responses to be a copy, got %v", got.IDs())
+	}
+	got.Extra = append(got.Extra, 6)
+	if again, _ := store.Get(1); len(again.Extra) != 1 {
+		t.Errorf("Expected Get to return a copy, got %v", again.Extra)
+	}
+
+	store.Delete(1)
+	if store.Len() != 0 {
+		t.Errorf("Expected an empty store after Delete, got %d", store.Len())
+	}
+
+	bot := &Bot{Responses: store}
+	store.Set(1, responses)
+	bot.clearResponses()
+	if store.Len() != 0 {
+		t.Errorf("Expected an empty store after clearResponses, got %d", store.Len())
+	}
+}
+
+func TestResponsesIDs(t *testing.T) {
+	if ids := (&Responses{Extra: []int64{1, 2}}).IDs(); !reflect.DeepEqual(ids, []int64{1, 2}) {
+		t.Errorf("Expected the extra responses without a primary, got %v", ids)
+	}
+}
+
+func TestMemoryResponseStorePrune(t *testing.T) {
+	store := NewMemoryResponseStore()
+	recent := (time.Now().UnixNano()/int64(time.Millisecond) - discordEpoch) << 22
+	store.Set(1, &Responses{Primary: 2})
+	store.Set(recent, &Responses{Primary: 3})
+
+	store.Prune(time.Now().Add(-time.Hour))
+	if r, _ := store.Get(1); r != nil {
+		t.Error("Expected the old responses to be pruned")
+	}
+	if r, _ := store.Get(recent); r == nil {
+		t.Error("Expected the recent responses to be kept")
+	}
+}
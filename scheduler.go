@@ -0,0 +1,177 @@
+package gocto
+
+import (
+	"container/heap"
+	"github.com/jonas747/discordgo"
+	"sync"
+	"time"
+)
+
+type scheduledDeletion struct {
+	channelID int64
+	messageID int64
+	at        time.Time
+	index     int
+}
+
+// deletionQueue is a heap of deletions ordered by when they are due.
+type deletionQueue []*scheduledDeletion
+
+func (q deletionQueue) Len() int           { return len(q) }
+func (q deletionQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+
+func (q deletionQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *deletionQueue) Push(x interface{}) {
+	item := x.(*scheduledDeletion)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *deletionQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return item
+}
+
+// DeleteScheduler deletes messages after a delay.
+// All the pending deletions are kept in one queue and waited on by a single goroutine so scheduling many of them is cheap.
+type DeleteScheduler struct {
+	Session  *discordgo.Session
+	lock     sync.Mutex
+	queue    deletionQueue
+	pending  map[int64]*scheduledDeletion // message ID -> deletion
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+	delete   func(channelID, messageID int64)
+}
+
+// NewDeleteScheduler creates a scheduler deleting messages with the session and starts it.
+func NewDeleteScheduler(s *discordgo.Session) *DeleteScheduler {
+	d := &DeleteScheduler{
+		Session: s,
+		pending: make(map[int64]*scheduledDeletion),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+	}
+	d.delete = func(channelID, messageID int64) {
+		d.Session.ChannelMessageDelete(channelID, messageID)
+	}
+	go d.run()
+	return d
+}
+
+// Schedule deletes the message after the delay, rescheduling it if it was already scheduled.
+func (d *DeleteScheduler) Schedule(channelID, messageID int64, after time.Duration) {
+	at := time.Now().Add(after)
+	d.lock.Lock()
+	if item, ok := d.pending[messageID]; ok {
+		item.at = at
+		heap.Fix(&d.queue, item.index)
+	} else {
+		item := &scheduledDeletion{channelID: channelID, messageID: messageID, at: at}
+		heap.Push(&d.queue, item)
+		d.pending[messageID] = item
+	}
+	d.lock.Unlock()
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Cancel cancels the deletion of the message, returns false if it wasn't scheduled.
+func (d *DeleteScheduler) Cancel(messageID int64) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	item, ok := d.pending[messageID]
+	if !ok {
+		return false
+	}
+	heap.Remove(&d.queue, item.index)
+	delete(d.pending, messageID)
+	return true
+}
+
+// Scheduled returns wether the message is scheduled to be deleted.
+func (d *DeleteScheduler) Scheduled(messageID int64) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, ok := d.pending[messageID]
+	return ok
+}
+
+// Len returns how many deletions are pending.
+func (d *DeleteScheduler) Len() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return len(d.queue)
+}
+
+// Stop stops the scheduler, the pending deletions are dropped.
+func (d *DeleteScheduler) Stop() {
+	d.stopOnce.Do(func() { close(d.stop) })
+}
+
+// due pops the deletions that are due and returns how long until the next one.
+func (d *DeleteScheduler) due() ([]*scheduledDeletion, time.Duration) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	now := time.Now()
+	var due []*scheduledDeletion
+	for len(d.queue) > 0 && !d.queue[0].at.After(now) {
+		item := heap.Pop(&d.queue).(*scheduledDeletion)
+		delete(d.pending, item.messageID)
+		due = append(due, item)
+	}
+	if len(d.queue) == 0 {
+		return due, time.Hour
+	}
+	return due, d.queue[0].at.Sub(now)
+}
+
+func (d *DeleteScheduler) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		due, wait := d.due()
+		for _, item := range due {
+			d.delete(item.channelID, item.messageID)
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-d.wake:
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// addDeletionListeners cancels the deletion of messages that are deleted.
+// Edited messages are cancelled by the edit listener, before the command handler re-runs and schedules them again.
+func addDeletionListeners(bot *Bot, s *discordgo.Session) {
+	s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDelete) {
+		bot.Deletions.Cancel(m.ID)
+	})
+	s.AddHandler(func(_ *discordgo.Session, m *discordgo.MessageDeleteBulk) {
+		for _, id := range m.Messages {
+			bot.Deletions.Cancel(id)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package gocto
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDeleteScheduler(t *testing.T) {
+	d := NewDeleteScheduler(nil)
+	defer d.Stop()
+
+	var lock sync.Mutex
+	var deleted []int64
+	done := make(chan struct{}, 10)
+	d.delete = func(channelID, messageID int64) {
+		lock.Lock()
+		deleted = append(deleted, messageID)
+		lock.Unlock()
+		done <- struct{}{}
+	}
+
+	d.Schedule(1, 10, 40*time.Millisecond)
+	d.Schedule(1, 11, 10*time.Millisecond)
+	d.Schedule(1, 12, 20*time.Millisecond)
+	d.Schedule(1, 13, 30*time.Millisecond)
+	if !d.Cancel(12) || d.Cancel(12) {
+		t.Error("Expected the first Cancel to cancel the deletion and the second to find nothing")
+	}
+	d.Schedule(1, 13, time.Hour) // Rescheduled past the end of the test.
+	if d.Len() != 3 || !d.Scheduled(13) {
+		t.Errorf("Expected 3 pending deletions, got %d", d.Len())
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for the deletions")
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(deleted) != 2 || deleted[0] != 11 || deleted[1] != 10 {
+		t.Errorf("Expected 11 then 10 to be deleted, got %v", deleted)
+	}
+	if d.Len() != 1 {
+		t.Errorf("Expected 13 to still be pending, got %d pending deletions", d.Len())
+	}
+}
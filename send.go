@@ -0,0 +1,282 @@
+package gocto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Noctember/gocto/helpers"
+	"github.com/jonas747/discordgo"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// MessageOptions is everything ctx.Send can send.
+type MessageOptions struct {
+	Content         string
+	Embed           *discordgo.MessageEmbed
+	Files           []*discordgo.File
+	TTS             bool
+	AllowedMentions *discordgo.AllowedMentions // Which mentions in the content ping, nil for Discord's default.
+	Reply           bool                       // Wether to send it as a reply to the invoking message.
+	NoEdit          bool                       // Wether to send a new message even if the command is editable.
+	Replace         bool                       // Wether editing the response replaces its content even if the command doesn't Override.
+	TTL             time.Duration              // How long until the message is deleted, overrides the command's ResponseTTL, negative to keep it.
+}
+
+// NoMentions allows no mentions to ping.
+var NoMentions = &discordgo.AllowedMentions{Parse: []discordgo.AllowedMentionType{}}
+
+type messageReference struct {
+	MessageID int64 `json:"message_id,string"`
+	ChannelID int64 `json:"channel_id,string"`
+	GuildID   int64 `json:"guild_id,string,omitempty"`
+}
+
+// messageSendReply is a discordgo.MessageSend with a message reference, which discordgo doesn't support yet.
+type messageSendReply struct {
+	*discordgo.MessageSend
+	MessageReference *messageReference `json:"message_reference"`
+}
+
+// Send sends a message with the options.
+// If the command is editable and was already responded to the response is edited instead,
+// responses with files can't be edited so the response of the previous run is deleted and sent again,
+// files sent after the response of the current run are sent as extra messages.
+// Every response is tracked in the bot's ResponseStore, when an edit re-runs the command the responses
+// that aren't edited are deleted.
+// The message is deleted after opts.TTL or the command's ResponseTTL, editing it restarts the delay.
+func (ctx *CommandContext) Send(opts MessageOptions) (*discordgo.Message, error) {
+	ctx.sendLock.Lock()
+	defer ctx.sendLock.Unlock()
+	rerun := !ctx.responded // The primary response, if any, was left by a previous run.
+	responses := ctx.responses()
+	editable := !opts.NoEdit && ctx.Command.Editable
+
+	if editable && responses.Primary != 0 && len(opts.Files) > 0 && !rerun {
+		// Keep the response sent by this run, the files go in a new message.
+		editable = false
+	}
+
+	if editable && responses.Primary != 0 {
+		if len(opts.Files) == 0 {
+			msg, err := ctx.edit(responses.Primary, opts)
+			if !isUnknownMessage(err) {
+				if err == nil {
+					ctx.expire(msg.ID, opts.TTL)
+				}
+				return msg, err
+			}
+			// The response was deleted, e.g by its TTL, send a new one.
+		} else {
+			ctx.Session.ChannelMessageDelete(ctx.Channel.ID, responses.Primary)
+		}
+		responses.Primary = 0
+	}
+
+	msg, err := ctx.send(opts)
+	if err != nil {
+		ctx.saveResponses(responses)
+		return nil, err
+	}
+	if editable {
+		responses.Primary = msg.ID
+	} else {
+		responses.Extra = append(responses.Extra, msg.ID)
+	}
+	ctx.saveResponses(responses)
+	ctx.expire(msg.ID, opts.TTL)
+	return msg, nil
+}
+
+// expire schedules the deletion of a response after ttl, or the command's ResponseTTL when ttl is 0.
+func (ctx *CommandContext) expire(messageID int64, ttl time.Duration) {
+	if ttl == 0 {
+		ttl = ctx.Command.ResponseTTL
+	}
+	if ttl > 0 {
+		ctx.Bot.Deletions.Schedule(ctx.Channel.ID, messageID, ttl)
+	} else {
+		// An edit may have turned the TTL off.
+		ctx.Bot.Deletions.Cancel(messageID)
+	}
+}
+
+// isUnknownMessage returns wether err is Discord's unknown message error.
+func isUnknownMessage(err error) bool {
+	restErr, ok := err.(*discordgo.RESTError)
+	return ok && restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownMessage
+}
+
+// edit edits the response m with the options.
+func (ctx *CommandContext) edit(m int64, opts MessageOptions) (*discordgo.Message, error) {
+	content := opts.Content
+	if !ctx.Command.Override && !opts.Replace && opts.Embed == nil {
+		old, err := ctx.Session.ChannelMessage(ctx.Channel.ID, m)
+		if err != nil {
+			return nil, err
+		}
+		content = old.Content + "\n" + content
+	}
+	edit := discordgo.NewMessageEdit(ctx.Channel.ID, m).SetContent(content)
+	if opts.Embed != nil {
+		edit.SetEmbed(opts.Embed)
+	}
+	edit.AllowedMentions = opts.AllowedMentions
+	return ctx.Session.ChannelMessageEditComplex(edit)
+}
+
+// appendedContent returns content after the content of the response that replying would append it to
+// when the command doesn't Override, and wether that response is from a previous run.
+func (ctx *CommandContext) appendedContent(content string) (string, bool, error) {
+	ctx.sendLock.Lock()
+	rerun := !ctx.responded
+	ctx.sendLock.Unlock()
+	if !ctx.Command.Editable || ctx.Command.Override {
+		return content, rerun, nil
+	}
+	responses, err := ctx.Bot.Responses.Get(ctx.Message.ID)
+	if err != nil || responses == nil || responses.Primary == 0 {
+		return content, rerun, err
+	}
+	old, err := ctx.Session.ChannelMessage(ctx.Channel.ID, responses.Primary)
+	if isUnknownMessage(err) {
+		return content, rerun, nil
+	}
+	if err != nil {
+		return content, rerun, err
+	}
+	return old.Content + "\n" + content, rerun, nil
+}
+
+// Sanitize sanitizes user input with helpers.DefaultSanitizer before echoing it, mentions are resolved to names from the state.
+// Don't use it on the args of replies of commands with a Sanitizer, they are already sanitized.
+func (ctx *CommandContext) Sanitize(text string) string {
+	return helpers.DefaultSanitizer.Sanitize(text, ctx.Session.State, ctx.Message.GuildID)
+}
+
+// sanitizeArgs sanitizes the string args and LocaleArgs values formatted into a reply with the command's Sanitizer,
+// the text they are formatted into is written by the command or the locale and is left alone.
+func (ctx *CommandContext) sanitizeArgs(args []interface{}) []interface{} {
+	sanitizer := ctx.Command.Sanitizer
+	if sanitizer == nil || len(args) == 0 {
+		return args
+	}
+	sanitize := func(arg interface{}) interface{} {
+		if s, ok := arg.(string); ok {
+			return sanitizer.Sanitize(s, ctx.Session.State, ctx.Message.GuildID)
+		}
+		return arg
+	}
+
+	res := make([]interface{}, len(args))
+	for i, arg := range args {
+		if named, ok := arg.(LocaleArgs); ok {
+			copied := make(LocaleArgs, len(named))
+			for k, v := range named {
+				copied[k] = sanitize(v)
+			}
+			res[i] = copied
+			continue
+		}
+		res[i] = sanitize(arg)
+	}
+	return res
+}
+
+// send sends a new message with the options.
+func (ctx *CommandContext) send(opts MessageOptions) (*discordgo.Message, error) {
+	data := &discordgo.MessageSend{
+		Content: opts.Content,
+		Embed:   opts.Embed,
+		Tts:     opts.TTS,
+		Files:   opts.Files,
+	}
+	if opts.AllowedMentions != nil {
+		data.AllowedMentions = *opts.AllowedMentions
+	}
+
+	if !opts.Reply {
+		return ctx.Session.ChannelMessageSendComplex(ctx.Channel.ID, data)
+	}
+
+	if data.Embed != nil && data.Embed.Type == "" {
+		data.Embed.Type = "rich"
+	}
+	endpoint := discordgo.EndpointChannelMessages(ctx.Channel.ID)
+	payload := &messageSendReply{
+		MessageSend: data,
+		MessageReference: &messageReference{
+			MessageID: ctx.Message.ID,
+			ChannelID: ctx.Message.ChannelID,
+			GuildID:   ctx.Message.GuildID,
+		},
+	}
+	var res []byte
+	var err error
+	if len(opts.Files) > 0 {
+		contentType, body, berr := multipartBody(payload, opts.Files)
+		if berr != nil {
+			return nil, berr
+		}
+		res, err = ctx.Session.RequestWithLockedBucket("POST", endpoint, contentType, body, ctx.Session.Ratelimiter.LockBucket(endpoint))
+	} else {
+		res, err = ctx.Session.RequestWithBucketID("POST", endpoint, payload, endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	msg := &discordgo.Message{}
+	if err := json.Unmarshal(res, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// multipartBody encodes the payload and the files like ChannelMessageSendComplex does,
+// which can't send the payload's message reference. Returns the content type and the body.
+func multipartBody(payload interface{}, files []*discordgo.File) (string, []byte, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="payload_json"`)
+	h.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(h)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", nil, err
+	}
+
+	for i, file := range files {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file%d"; filename="%s"`, i, quoteEscaper.Replace(file.Name)))
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		h.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(h)
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", nil, err
+	}
+	return writer.FormDataContentType(), body.Bytes(), nil
+}
@@ -0,0 +1,67 @@
+package gocto
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Noctember/gocto/helpers"
+	"github.com/jonas747/discordgo"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestMessageSendReply(t *testing.T) {
+	data, err := json.Marshal(&messageSendReply{
+		MessageSend:      &discordgo.MessageSend{Content: "hi", AllowedMentions: *NoMentions},
+		MessageReference: &messageReference{MessageID: 1, ChannelID: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`"content":"hi"`, `"parse":[]`, `"message_reference":{"message_id":"1","channel_id":"2"}`} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected %s in %s", expected, data)
+		}
+	}
+}
+
+func TestMultipartBody(t *testing.T) {
+	payload := &messageSendReply{
+		MessageSend:      &discordgo.MessageSend{Content: "hi"},
+		MessageReference: &messageReference{MessageID: 1, ChannelID: 2},
+	}
+	contentType, body, err := multipartBody(payload, []*discordgo.File{{Name: "a.txt", Reader: strings.NewReader("file")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if json := form.Value["payload_json"]; len(json) != 1 || !strings.Contains(json[0], `"message_reference":{"message_id":"1","channel_id":"2"}`) {
+		t.Errorf("Expected the payload to have the message reference, got %q", json)
+	}
+	if files := form.File["file0"]; len(files) != 1 || files[0].Filename != "a.txt" {
+		t.Errorf("Expected the file, got %v", files)
+	}
+}
+
+func TestSanitizeArgs(t *testing.T) {
+	ctx := &CommandContext{
+		Command: NewCommand("say", "General", nil).SetSanitizer(helpers.NewSanitizer()),
+		Session: &discordgo.Session{},
+		Message: &discordgo.Message{},
+	}
+	args := ctx.sanitizeArgs([]interface{}{"**@everyone**", 1, LocaleArgs{"name": "_x_", "count": 2}})
+	if args[0] != "\\*\\*@\u200beveryone\\*\\*" || args[1] != 1 {
+		t.Errorf("Expected the string arg to be sanitized, got %q", args)
+	}
+	if named := args[2].(LocaleArgs); named["name"] != "\\_x\\_" || named["count"] != 2 {
+		t.Errorf("Expected the string LocaleArgs values to be sanitized, got %v", named)
+	}
+}
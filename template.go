@@ -0,0 +1,164 @@
+package gocto
+
+import (
+	"encoding/json"
+	"github.com/jonas747/discordgo"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// ToJSON encodes the embed in Discord's embed JSON format.
+func (e *Embed) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(e.MessageEmbed, "", "  ")
+}
+
+// EmbedFromJSON decodes an embed in Discord's embed JSON format.
+func EmbedFromJSON(data []byte) (*Embed, error) {
+	em := NewEmbed()
+	if err := json.Unmarshal(data, em.MessageEmbed); err != nil {
+		return nil, err
+	}
+	return em, nil
+}
+
+// copyEmbed returns a deep copy of em.
+func copyEmbed(em *Embed) (*Embed, error) {
+	res := *em
+	res.MessageEmbed = &discordgo.MessageEmbed{}
+	raw, err := json.Marshal(em.MessageEmbed)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, res.MessageEmbed); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// EmbedTemplate is an embed with placeholders in its texts, filled when it is executed.
+// The texts are formatted like locale strings with the data e.g "{count, plural, one{# member} other{# members}}"
+// and {@KEY} is replaced with the locale key KEY formatted with the same data.
+type EmbedTemplate struct {
+	Embed *discordgo.MessageEmbed
+}
+
+func NewEmbedTemplate(embed *discordgo.MessageEmbed) *EmbedTemplate {
+	return &EmbedTemplate{Embed: embed}
+}
+
+// ParseEmbedTemplate parses a template in Discord's embed JSON format.
+func ParseEmbedTemplate(data []byte) (*EmbedTemplate, error) {
+	em, err := EmbedFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewEmbedTemplate(em.MessageEmbed), nil
+}
+
+// formatTemplate formats a text of a template with the data, {@KEY} placeholders are localized with the same data
+// and can be used anywhere a placeholder can e.g in plural branches.
+func formatTemplate(lang *Language, localize func(key string, args ...interface{}) string, text string, data LocaleArgs) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	return formatMessage(lang, localize, text, data)
+}
+
+// Execute fills the template with the data, lang formats the numbers and plurals and localize resolves the locale keys.
+func (t *EmbedTemplate) Execute(lang *Language, localize func(key string, args ...interface{}) string, data LocaleArgs) (*Embed, error) {
+	if lang == nil {
+		lang = English
+	}
+	if localize == nil {
+		localize = lang.Get
+	}
+	if data == nil {
+		data = LocaleArgs{}
+	}
+
+	// Copy the template through JSON so executing it doesn't modify it.
+	raw, err := json.Marshal(t.Embed)
+	if err != nil {
+		return nil, err
+	}
+	em, err := EmbedFromJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := []*string{&em.Title, &em.Description, &em.URL}
+	if em.Footer != nil {
+		texts = append(texts, &em.Footer.Text, &em.Footer.IconURL)
+	}
+	if em.Author != nil {
+		texts = append(texts, &em.Author.Name, &em.Author.URL, &em.Author.IconURL)
+	}
+	if em.Image != nil {
+		texts = append(texts, &em.Image.URL)
+	}
+	if em.Thumbnail != nil {
+		texts = append(texts, &em.Thumbnail.URL)
+	}
+	for _, field := range em.Fields {
+		texts = append(texts, &field.Name, &field.Value)
+	}
+
+	for _, text := range texts {
+		if *text, err = formatTemplate(lang, localize, *text, data); err != nil {
+			return nil, err
+		}
+	}
+	return em.Truncate(), nil
+}
+
+// AddTemplate registers a named embed template, replacing the one with the same name.
+func (bot *Bot) AddTemplate(name string, template *EmbedTemplate) *Bot {
+	bot.Templates[name] = template
+	return bot
+}
+
+// LoadTemplates registers every .json file in dir as an embed template named after the file without its extension.
+func (bot *Bot) LoadTemplates(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		template, err := ParseEmbedTemplate(data)
+		if err != nil {
+			return err
+		}
+		bot.AddTemplate(strings.TrimSuffix(filepath.Base(file), ".json"), template)
+	}
+	return nil
+}
+
+// Template executes the named template in the context's locale, returns nil if there is no such template.
+// Formatting errors are returned.
+func (ctx *CommandContext) Template(name string, data LocaleArgs) (*Embed, error) {
+	template, ok := ctx.Bot.Templates[name]
+	if !ok {
+		return nil, nil
+	}
+	return template.Execute(ctx.Locale, ctx.Localize, data)
+}
+
+// TemplateFunc returns a function that executes the named template e.g for Paginator.Template,
+// falling back to fallback if the template is missing or fails to execute.
+func (ctx *CommandContext) TemplateFunc(name string, data LocaleArgs, fallback func() *Embed) func() *Embed {
+	return func() *Embed {
+		em, err := ctx.Template(name, data)
+		if err != nil {
+			ctx.Bot.ErrorHandler(ctx.Bot, err)
+		}
+		if em == nil {
+			return fallback()
+		}
+		return em
+	}
+}
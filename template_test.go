@@ -0,0 +1,63 @@
+package gocto
+
+import (
+	"fmt"
+	"github.com/jonas747/discordgo"
+	"testing"
+)
+
+func TestEmbedJSON(t *testing.T) {
+	em := NewEmbed().SetTitle("Title").SetColor(0xff0000).AddInlineField("name", "value").SetFooter("footer")
+	data, err := em.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := EmbedFromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Title != "Title" || res.Color != 0xff0000 || len(res.Fields) != 1 || !res.Fields[0].Inline || res.Footer.Text != "footer" {
+		t.Errorf("Unexpected embed after a round trip %s", data)
+	}
+}
+
+func TestEmbedTemplate(t *testing.T) {
+	template, err := ParseEmbedTemplate([]byte(`{
+		"title": "{@PAGINATOR_FOOTER}",
+		"description": "{count, plural, one{# member} other{# members}} in {guild}",
+		"fields": [{"name": "{guild}", "value": "{count, number}"}],
+		"color": 255
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	em, err := template.Execute(nil, nil, LocaleArgs{"count": 1500, "guild": "Gophers", "page": 1, "total": 2, "extra": ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if em.Title != "Page 1/2 " {
+		t.Errorf("Unexpected title %q", em.Title)
+	}
+	if em.Description != "1,500 members in Gophers" {
+		t.Errorf("Unexpected description %q", em.Description)
+	}
+	if em.Fields[0].Name != "Gophers" || em.Fields[0].Value != "1,500" || em.Color != 255 {
+		t.Errorf("Unexpected field %+v", em.Fields[0])
+	}
+	if template.Embed.Description != "{count, plural, one{# member} other{# members}} in {guild}" {
+		t.Error("Executing the template modified it")
+	}
+
+	if _, err := template.Execute(nil, nil, LocaleArgs{}); err == nil {
+		t.Error("Expected an error for missing data")
+	}
+
+	branches := NewEmbedTemplate(&discordgo.MessageEmbed{Description: "{n, plural, one {{@KEY_ONE}} other {{@KEY_OTHER}}}"})
+	localize := func(key string, args ...interface{}) string {
+		return key + " " + fmt.Sprint(args[0].(LocaleArgs)["n"])
+	}
+	if em, err := branches.Execute(nil, localize, LocaleArgs{"n": 2}); err != nil || em.Description != "KEY_OTHER 2" {
+		t.Errorf("Expected the key in the plural branch to be localized, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package gocto
 
 import (
 	"regexp"
+	"time"
 )
 
 var escapeReg = regexp.MustCompile("@(everyone|here)")
@@ -9,3 +10,12 @@ var escapeReg = regexp.MustCompile("@(everyone|here)")
 func Escape(input string) string {
 	return escapeReg.ReplaceAllString(input, "@\u200b$1")
 }
+
+// discordEpoch is the first millisecond of 2015 in Unix milliseconds, snowflakes count from it.
+const discordEpoch = 1420070400000
+
+// SnowflakeTime returns when the snowflake ID was created.
+func SnowflakeTime(id int64) time.Time {
+	ms := id>>22 + discordEpoch
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond))
+}
@@ -15,3 +15,10 @@ func TestEscape(t *testing.T) {
 		t.Error("Escape didn't return the expectd output for @here")
 	}
 }
+
+func TestSnowflakeTime(t *testing.T) {
+	// The ID of a message sent on 2016-04-30 11:18:25.796 UTC.
+	if res := SnowflakeTime(175928847299117063).UTC().Format("2006-01-02 15:04:05.000"); res != "2016-04-30 11:18:25.796" {
+		t.Errorf("Expected 2016-04-30 11:18:25.796 but got %s", res)
+	}
+}